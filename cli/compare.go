@@ -0,0 +1,330 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/formatter"
+	"github.com/mgechev/revive/lint"
+)
+
+// Exit codes of the compare command.
+const (
+	compareOK         = 0
+	compareFailed     = 1 // the reports cannot be compared
+	compareRegression = 2 // the new report has failures the gate does not accept
+)
+
+// Gates of the -fail-on flag of the compare command.
+const (
+	failOnNew       = "new"        // any new failure
+	failOnNewErrors = "new-errors" // any new failure with the error severity
+	failOnIncrease  = "increase"   // more new failures than fixed ones
+	failOnNone      = "none"       // never
+)
+
+// reportFailure is a failure read from a report of the json or ndjson formatters.
+type reportFailure struct {
+	Rule        string        `json:"rule"`
+	Severity    lint.Severity `json:"severity"`
+	Message     string        `json:"message"`
+	Path        string        `json:"path"`
+	Fingerprint string        `json:"fingerprint"`
+}
+
+// report is a report of the json or ndjson formatters.
+type report struct {
+	SchemaVersion int `json:"schemaVersion"`
+	Revive        struct {
+		ReviveVersion string `json:"reviveVersion"`
+		ConfigHash    string `json:"configHash"`
+	} `json:"revive"`
+	Failures []reportFailure `json:"failures"`
+}
+
+// readReport reads a report of the json formatter, or of the ndjson formatter.
+func readReport(content []byte) (*report, error) {
+	var result report
+	var record struct {
+		Type string `json:"type"`
+	}
+	dec := json.NewDecoder(bytes.NewReader(content))
+	var raw json.RawMessage
+	if err := dec.Decode(&raw); err != nil {
+		return nil, fmt.Errorf("invalid report: %w", err)
+	}
+	if err := json.Unmarshal(raw, &record); err != nil {
+		return nil, fmt.Errorf("invalid report: expected a JSON object: %w", err)
+	}
+
+	if record.Type == "" {
+		// a json report
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, fmt.Errorf("invalid report: %w", err)
+		}
+		if dec.More() {
+			return nil, errors.New("invalid report: unexpected content after the report")
+		}
+	} else {
+		// an ndjson report, starting with its header
+		if record.Type != "header" {
+			return nil, fmt.Errorf("invalid report: expected a header record, got %q", record.Type)
+		}
+		if err := json.Unmarshal(raw, &result); err != nil {
+			return nil, fmt.Errorf("invalid report: %w", err)
+		}
+		for dec.More() {
+			var failure struct {
+				Type string `json:"type"`
+				reportFailure
+			}
+			if err := dec.Decode(&failure); err != nil {
+				return nil, fmt.Errorf("invalid report: %w", err)
+			}
+			if failure.Type == "failure" {
+				result.Failures = append(result.Failures, failure.reportFailure)
+			}
+		}
+	}
+
+	if result.SchemaVersion != formatter.JSONSchemaVersion {
+		return nil, fmt.Errorf("unsupported report: expected the schema version %d, got %d", formatter.JSONSchemaVersion, result.SchemaVersion)
+	}
+	for _, failure := range result.Failures {
+		if failure.Fingerprint == "" {
+			return nil, fmt.Errorf("invalid report: the failure %q of %s has no fingerprint", failure.Message, failure.Path)
+		}
+	}
+
+	return &result, nil
+}
+
+// compareCounts are the numbers of failures of a comparison.
+type compareCounts struct {
+	New       int `json:"new"`
+	Fixed     int `json:"fixed"`
+	Unchanged int `json:"unchanged"`
+}
+
+// comparison is the result of the comparison of an old report with a new one.
+type comparison struct {
+	total       compareCounts
+	rules       map[string]*compareCounts
+	directories map[string]*compareCounts
+	// newErrors is the number of new failures with the error severity.
+	newErrors int
+}
+
+// compareReports matches the failures of the reports by fingerprint.
+// Failures sharing a fingerprint are matched in the order of the reports.
+func compareReports(old, current *report) *comparison {
+	c := &comparison{rules: map[string]*compareCounts{}, directories: map[string]*compareCounts{}}
+
+	unmatched := map[string][]reportFailure{}
+	for _, failure := range old.Failures {
+		unmatched[failure.Fingerprint] = append(unmatched[failure.Fingerprint], failure)
+	}
+
+	for _, failure := range current.Failures {
+		if previous := unmatched[failure.Fingerprint]; len(previous) > 0 {
+			unmatched[failure.Fingerprint] = previous[1:]
+			c.add(failure, func(counts *compareCounts) { counts.Unchanged++ })
+			continue
+		}
+
+		c.add(failure, func(counts *compareCounts) { counts.New++ })
+		if failure.Severity == lint.SeverityError {
+			c.newErrors++
+		}
+	}
+
+	for _, failure := range old.Failures {
+		if previous := unmatched[failure.Fingerprint]; len(previous) > 0 {
+			unmatched[failure.Fingerprint] = previous[1:]
+			c.add(failure, func(counts *compareCounts) { counts.Fixed++ })
+		}
+	}
+
+	return c
+}
+
+// add counts a failure in the total, and in the counts of its rule and of its directory.
+func (c *comparison) add(failure reportFailure, count func(*compareCounts)) {
+	count(&c.total)
+	count(countsOf(c.rules, failure.Rule))
+	count(countsOf(c.directories, path.Dir(failure.Path)))
+}
+
+// countsOf returns the counts of the given key, adding them if needed.
+func countsOf(counts map[string]*compareCounts, key string) *compareCounts {
+	if counts[key] == nil {
+		counts[key] = &compareCounts{}
+	}
+	return counts[key]
+}
+
+// regression tells if the comparison does not pass the given gate.
+func (c *comparison) regression(failOn string) bool {
+	switch failOn {
+	case failOnNew:
+		return c.total.New > 0
+	case failOnNewErrors:
+		return c.newErrors > 0
+	case failOnIncrease:
+		return c.total.New > c.total.Fixed
+	default:
+		return false
+	}
+}
+
+// print writes the counts by rule and by directory, sorted by new failures, then fixed ones, then name.
+func (c *comparison) print(w io.Writer) error {
+	for _, group := range []struct {
+		title  string
+		counts map[string]*compareCounts
+	}{
+		{"Rule", c.rules},
+		{"Directory", c.directories},
+	} {
+		if len(group.counts) == 0 {
+			continue
+		}
+
+		tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+		fmt.Fprintf(tw, "%s\tNew\tFixed\tUnchanged\n", group.title)
+		names := slices.SortedFunc(maps.Keys(group.counts), func(a, b string) int {
+			ca, cb := group.counts[a], group.counts[b]
+			return cmp.Or(cmp.Compare(cb.New, ca.New), cmp.Compare(cb.Fixed, ca.Fixed), cmp.Compare(a, b))
+		})
+		for _, name := range names {
+			counts := group.counts[name]
+			fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", name, counts.New, counts.Fixed, counts.Unchanged)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		if _, err := fmt.Fprintln(w); err != nil {
+			return err
+		}
+	}
+
+	_, err := fmt.Fprintf(w, "%d new, %d fixed and %d unchanged failures\n", c.total.New, c.total.Fixed, c.total.Unchanged)
+	return err
+}
+
+// trendRecord is a line of the trend file, appended at each comparison.
+type trendRecord struct {
+	Date          time.Time      `json:"date"`
+	ReviveVersion string         `json:"reviveVersion"`
+	ConfigHash    string         `json:"configHash"`
+	Failures      int            `json:"failures"`
+	Rules         map[string]int `json:"rules"`
+	compareCounts
+}
+
+// newTrendRecord returns the trend record of the new report of a comparison.
+func newTrendRecord(date time.Time, current *report, c *comparison) trendRecord {
+	rules := map[string]int{}
+	for _, failure := range current.Failures {
+		rules[failure.Rule]++
+	}
+
+	return trendRecord{
+		Date:          date.UTC(),
+		ReviveVersion: current.Revive.ReviveVersion,
+		ConfigHash:    current.Revive.ConfigHash,
+		Failures:      len(current.Failures),
+		Rules:         rules,
+		compareCounts: c.total,
+	}
+}
+
+// appendTrend appends the record to the trend file, a JSON object per line, creating the file if needed.
+func appendTrend(filename string, record trendRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	f, err := AppFs.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("cannot open the trend file: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("cannot write the trend file: %w", err)
+	}
+	return f.Close()
+}
+
+// runCompare runs the compare command with its arguments, and returns its exit code.
+func runCompare(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("compare", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: revive compare [flags] old.json new.json")
+		fmt.Fprintln(stderr, "\nCompares two reports of the json or ndjson formatters, matching their failures by fingerprint.")
+		fmt.Fprintf(stderr, "Exits with %d if the gate fails, %d if the reports cannot be compared.\n\n", compareRegression, compareFailed)
+		flags.PrintDefaults()
+	}
+	failOn := flags.String("fail-on", failOnNew, "gate on regressions: new (any new failure), new-errors (any new failure with the error severity), increase (more new than fixed failures), or none")
+	trend := flags.String("trend", "", "path of the file to append the counts of the new report to, a JSON object per line")
+	if err := flags.Parse(args); err != nil {
+		return compareFailed
+	}
+	if !slices.Contains([]string{failOnNew, failOnNewErrors, failOnIncrease, failOnNone}, *failOn) {
+		fmt.Fprintf(stderr, "unknown -fail-on gate %q\n", *failOn)
+		return compareFailed
+	}
+	if flags.NArg() != 2 {
+		flags.Usage()
+		return compareFailed
+	}
+
+	reports := make([]*report, 2)
+	for i, filename := range flags.Args() {
+		content, err := afero.ReadFile(AppFs, filename)
+		if err != nil {
+			fmt.Fprintf(stderr, "cannot read the report: %v\n", err)
+			return compareFailed
+		}
+		if reports[i], err = readReport(content); err != nil {
+			fmt.Fprintf(stderr, "%s: %v\n", filename, err)
+			return compareFailed
+		}
+	}
+	old, current := reports[0], reports[1]
+	if old.Revive.ConfigHash != current.Revive.ConfigHash {
+		fmt.Fprintln(stderr, "warning: the reports have different configuration hashes, some failures can come from the change of configuration")
+	}
+
+	c := compareReports(old, current)
+	if err := c.print(stdout); err != nil {
+		fmt.Fprintln(stderr, err)
+		return compareFailed
+	}
+	if *trend != "" {
+		if err := appendTrend(*trend, newTrendRecord(time.Now(), current, c)); err != nil {
+			fmt.Fprintln(stderr, err)
+			return compareFailed
+		}
+	}
+
+	if c.regression(*failOn) {
+		return compareRegression
+	}
+	return compareOK
+}
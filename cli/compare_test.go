@@ -0,0 +1,145 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	oldReport = `{"schemaVersion":1,"revive":{"reviveVersion":"1.0.0","configHash":"h"},"failures":[
+{"rule":"exported","severity":"warning","message":"m1","path":"a/a.go","fingerprint":"f1"},
+{"rule":"exported","severity":"warning","message":"m1","path":"a/a.go","fingerprint":"f1"},
+{"rule":"errorf","severity":"warning","message":"m2","path":"b/b.go","fingerprint":"f2"}]}`
+
+	newReport = `{"type":"header","schemaVersion":1,"revive":{"reviveVersion":"1.1.0","configHash":"h"}}
+{"type":"failure","rule":"exported","severity":"warning","message":"m1","path":"a/a.go","fingerprint":"f1"}
+{"type":"failure","rule":"var-naming","severity":"error","message":"m3","path":"a/c.go","fingerprint":"f3"}
+{"type":"footer","failures":2,"errors":1,"warnings":1}
+`
+)
+
+func TestReadReport(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		content  string
+		failures int
+		wantErr  string
+	}{
+		{name: "json", content: oldReport, failures: 3},
+		{name: "ndjson", content: newReport, failures: 2},
+		{name: "empty", content: `{"schemaVersion":1,"failures":[]}`},
+		{name: "unknown schema", content: `{"schemaVersion":2,"failures":[]}`, wantErr: "unsupported report"},
+		{name: "no fingerprint", content: `{"schemaVersion":1,"failures":[{"rule":"r","path":"a.go"}]}`, wantErr: "no fingerprint"},
+		{name: "not a report", content: `[1, 2]`, wantErr: "expected a JSON object"},
+		{name: "ndjson without header", content: `{"type":"failure","fingerprint":"f"}`, wantErr: "expected a header record"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := readReport([]byte(tt.content))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(r.Failures) != tt.failures {
+				t.Errorf("got %d failures, want %d", len(r.Failures), tt.failures)
+			}
+		})
+	}
+}
+
+func TestCompareReports(t *testing.T) {
+	old, err := readReport([]byte(oldReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+	current, err := readReport([]byte(newReport))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c := compareReports(old, current)
+
+	if want := (compareCounts{New: 1, Fixed: 2, Unchanged: 1}); c.total != want {
+		t.Errorf("got total %+v, want %+v", c.total, want)
+	}
+	if want := (compareCounts{Fixed: 1, Unchanged: 1}); *c.rules["exported"] != want {
+		t.Errorf("got exported %+v, want %+v", *c.rules["exported"], want)
+	}
+	if want := (compareCounts{New: 1, Fixed: 1, Unchanged: 1}); *c.directories["a"] != want {
+		t.Errorf("got directory a %+v, want %+v", *c.directories["a"], want)
+	}
+
+	for failOn, want := range map[string]bool{failOnNew: true, failOnNewErrors: true, failOnIncrease: false, failOnNone: false} {
+		if got := c.regression(failOn); got != want {
+			t.Errorf("regression(%q) = %v, want %v", failOn, got, want)
+		}
+	}
+
+	var out strings.Builder
+	if err := c.print(&out); err != nil {
+		t.Fatal(err)
+	}
+	want := `Rule        New  Fixed  Unchanged
+var-naming  1    0      0
+errorf      0    1      0
+exported    0    1      1
+
+Directory  New  Fixed  Unchanged
+a          1    1      1
+b          0    1      0
+
+1 new, 2 fixed and 1 unchanged failures
+`
+	if got := out.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestRunCompare(t *testing.T) {
+	t.Cleanup(func() { AppFs = afero.NewMemMapFs() })
+	afero.WriteFile(AppFs, "old.json", []byte(oldReport), 0o644)
+	afero.WriteFile(AppFs, "new.ndjson", []byte(newReport), 0o644)
+
+	for _, tt := range []struct {
+		args []string
+		want int
+	}{
+		{args: []string{"old.json", "new.ndjson"}, want: compareRegression},
+		{args: []string{"-fail-on", "increase", "old.json", "new.ndjson"}, want: compareOK},
+		{args: []string{"new.ndjson", "old.json"}, want: compareRegression},
+		{args: []string{"-fail-on", "new-errors", "new.ndjson", "old.json"}, want: compareOK},
+		{args: []string{"-fail-on", "unknown", "old.json", "new.ndjson"}, want: compareFailed},
+		{args: []string{"old.json"}, want: compareFailed},
+		{args: []string{"old.json", "missing.json"}, want: compareFailed},
+		{args: []string{"-trend", "trend.ndjson", "old.json", "new.ndjson"}, want: compareRegression},
+		{args: []string{"-trend", "trend.ndjson", "-fail-on", "none", "old.json", "new.ndjson"}, want: compareOK},
+	} {
+		var stdout, stderr strings.Builder
+		if got := runCompare(tt.args, &stdout, &stderr); got != tt.want {
+			t.Errorf("%v: got exit code %d, want %d (%s)", tt.args, got, tt.want, stderr.String())
+		}
+	}
+
+	content, err := afero.ReadFile(AppFs, "trend.ndjson")
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(content), "\n"), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d trend records, want 2", len(lines))
+	}
+	var record trendRecord
+	if err := json.Unmarshal([]byte(lines[1]), &record); err != nil {
+		t.Fatal(err)
+	}
+	if record.ReviveVersion != "1.1.0" || record.Failures != 2 || record.New != 1 || record.Fixed != 2 || record.Rules["var-naming"] != 1 {
+		t.Errorf("unexpected trend record %+v", record)
+	}
+}
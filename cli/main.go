@@ -2,17 +2,23 @@
 package cli
 
 import (
+	"context"
 	"flag"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime/debug"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/spf13/afero"
 
 	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/formatter"
+	"github.com/mgechev/revive/lint"
 	"github.com/mgechev/revive/revivelib"
 )
 
@@ -28,7 +34,7 @@ var (
 	commit  = defaultCommit
 	date    = defaultDate
 	builtBy = defaultBuilder
-	// AppFs is used for operations related to user config files.
+	// AppFs is used for operations related to user config files and output files.
 	AppFs = afero.NewOsFs()
 )
 
@@ -39,6 +45,13 @@ func fail(err string) {
 
 // RunRevive runs the CLI for revive.
 func RunRevive(extraRules ...revivelib.ExtraRule) {
+	switch subcommand(os.Args[1:]) {
+	case "compare":
+		os.Exit(runCompare(os.Args[2:], os.Stdout, os.Stderr)) //revive:disable-line:deep-exit
+	case "serve":
+		os.Exit(runServe(os.Args[2:], extraRules, os.Stderr)) //revive:disable-line:deep-exit
+	}
+
 	// Move parsing flags outside of init(); otherwise, tests don't work properly.
 	// More info: https://github.com/golang/go/issues/46869#issuecomment-865695953
 	initConfig()
@@ -48,19 +61,86 @@ func RunRevive(extraRules ...revivelib.ExtraRule) {
 		return
 	}
 
-	conf, err := config.GetConfig(configPath)
+	if version != defaultVersion {
+		formatter.Version = version
+	}
+
+	conf, err := loadConfig()
+	if err != nil {
+		fail(err.Error())
+	}
+
+	if watchFlag {
+		runWatch(conf, extraRules)
+		return
+	}
+
+	stats := newRunStats()
+	start := time.Now()
+	exitCode, err := runLint(conf, nil, stats, extraRules)
 	if err != nil {
 		fail(err.Error())
 	}
 
+	if statsFlag {
+		stats.print(os.Stderr, time.Since(start))
+		printExcludeRules(os.Stderr, conf.ExcludeRules)
+	}
+
+	os.Exit(exitCode) //revive:disable-line:deep-exit
+}
+
+// loadConfig reads the configuration of the -config and -template flags.
+func loadConfig() (*lint.Config, error) {
+	conf, err := config.GetConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	resolveTemplateFile(conf, filepath.Dir(configPath))
+	if templatePath != "" {
+		setTemplateFile(conf, templatePath)
+	}
+	return conf, nil
+}
+
+// runLint lints the packages of the command line, keeping them in the cache if not nil,
+// writes the failures to the outputs and returns the exit code of the run.
+func runLint(conf *lint.Config, cache *lint.Cache, stats *runStats, extraRules []revivelib.ExtraRule) (int, error) {
+	outputConfigs := conf.Outputs
+	if len(formatters) > 0 {
+		outputConfigs = nil
+		for _, value := range formatters {
+			outputConfigs = append(outputConfigs, parseOutputFlag(value))
+		}
+	}
+	if usesTemplate(outputConfigs) {
+		var err error
+		if conf, err = readTemplateFile(conf); err != nil {
+			return 0, err
+		}
+	}
+
 	revive, err := revivelib.New(
 		conf,
-		setExitStatus,
-		maxOpenFiles,
-		extraRules...,
+		revivelib.WithSetExitStatus(setExitStatus),
+		revivelib.WithMaxOpenFiles(maxOpenFiles),
+		revivelib.WithExtraRules(extraRules...),
+		revivelib.WithBuildTags(splitBuildTags(buildTags)...),
+		revivelib.WithFileReports(stats.add),
+		revivelib.WithSortOrder(revivelib.SortOrder(sortOrder)),
+		revivelib.WithCodeOwners(codeOwnersPath),
+		revivelib.WithOwners(owners...),
+		revivelib.WithWarnings(os.Stderr),
+		revivelib.WithGitIgnore(gitIgnore),
+		revivelib.WithCache(cache),
 	)
 	if err != nil {
-		fail(err.Error())
+		return 0, err
+	}
+
+	outputs, err := openOutputs(outputConfigs)
+	if err != nil {
+		return 0, err
 	}
 
 	files := flag.Args()
@@ -74,30 +154,34 @@ func RunRevive(extraRules ...revivelib.ExtraRule) {
 		packages = append(packages, revivelib.Exclude(file))
 	}
 
-	failures, err := revive.Lint(packages...)
+	run, err := revive.StartLint(context.Background(), packages...)
 	if err != nil {
-		fail(err.Error())
-	}
-
-	output, exitCode, err := revive.Format(formatterName, failures)
-	if err != nil {
-		fail(err.Error())
+		closeOutputs(outputs)
+		return 0, err
 	}
 
-	if output != "" {
-		fmt.Println(output)
+	exitCode, err := revive.FormatRun(revivelibOutputs(outputs), run)
+	if closeErr := closeOutputs(outputs); err == nil {
+		err = closeErr
 	}
-
-	os.Exit(exitCode) //revive:disable-line:deep-exit
+	return exitCode, err
 }
 
 var (
 	configPath      string
 	excludePatterns revivelib.ArrayFlags
-	formatterName   string
+	formatters      revivelib.ArrayFlags
 	versionFlag     bool
 	setExitStatus   bool
 	maxOpenFiles    int
+	buildTags       string
+	statsFlag       bool
+	templatePath    string
+	sortOrder       string
+	codeOwnersPath  string
+	owners          revivelib.ArrayFlags
+	gitIgnore       bool
+	watchFlag       bool
 )
 
 var originalUsage = flag.Usage
@@ -159,23 +243,102 @@ func initConfig() {
 	const (
 		configUsage       = "path to the configuration TOML file, defaults to $XDG_CONFIG_HOME/revive.toml or $HOME/revive.toml, if present (i.e. -config myconf.toml)"
 		excludeUsage      = "list of globs which specify files to be excluded (i.e. -exclude foo/...)"
-		formatterUsage    = "formatter to be used for the output (i.e. -formatter stylish)"
+		formatterUsage    = "formatter to be used for the output, optionally followed by the file to write to; can be repeated (i.e. -formatter stylish -formatter sarif:revive.sarif)"
 		versionUsage      = "get revive version"
 		exitStatusUsage   = "set exit status to 1 if any issues are found, overwrites errorCode and warningCode in config"
 		maxOpenFilesUsage = "maximum number of open files at the same time"
+		buildTagsUsage    = "comma-separated list of build tags to consider satisfied when selecting the files to lint (i.e. -tags integration,e2e)"
+		statsUsage        = "print statistics about the run, like the number of linted files and their Go language versions, to the standard error"
+		sortUsage         = "order of the failures: position (by file, line, column, then rule), rule, severity, or none to write the failures as they are found"
+		templateUsage     = "path to the text/template file of the template formatter (i.e. -formatter template -template report.tmpl)"
+		codeOwnersUsage   = "path to the CODEOWNERS file giving the owners of the failures, defaults to the one of the repository, if present"
+		gitIgnoreUsage    = "skip the files ignored by the .gitignore files of the repository, in addition to the ones of its .reviveignore files"
+		ownerUsage        = "only report the failures of the files of an owner of the CODEOWNERS file; can be repeated (i.e. -owner @org/team)"
+		watchUsage        = "keep running, and lint again the packages whose files change, as well as all the packages when go.mod or the configuration change"
 	)
 
 	defaultConfigPath := buildDefaultConfigPath()
 
 	flag.StringVar(&configPath, "config", defaultConfigPath, configUsage)
 	flag.Var(&excludePatterns, "exclude", excludeUsage)
-	flag.StringVar(&formatterName, "formatter", "", formatterUsage)
+	flag.Var(&formatters, "formatter", formatterUsage)
 	flag.BoolVar(&versionFlag, "version", false, versionUsage)
 	flag.BoolVar(&setExitStatus, "set_exit_status", false, exitStatusUsage)
 	flag.IntVar(&maxOpenFiles, "max_open_files", 0, maxOpenFilesUsage)
+	flag.StringVar(&buildTags, "tags", "", buildTagsUsage)
+	flag.BoolVar(&statsFlag, "stats", false, statsUsage)
+	flag.StringVar(&templatePath, "template", "", templateUsage)
+	flag.StringVar(&sortOrder, "sort", string(revivelib.SortByPosition), sortUsage)
+	flag.StringVar(&codeOwnersPath, "codeowners", "", codeOwnersUsage)
+	flag.Var(&owners, "owner", ownerUsage)
+	flag.BoolVar(&gitIgnore, "gitignore", false, gitIgnoreUsage)
+	flag.BoolVar(&watchFlag, "watch", false, watchUsage)
 	flag.Parse()
 }
 
+// setTemplateFile sets the file of the template formatter, overriding the template of the configuration.
+func setTemplateFile(conf *lint.Config, path string) {
+	name := (&formatter.Template{}).Name()
+	if conf.Formatters == nil {
+		conf.Formatters = lint.FormattersConfig{}
+	}
+	conf.Formatters[name] = lint.FormatterConfig{formatter.TemplateFileOption: path}
+}
+
+// resolveTemplateFile makes the relative path of the file of the template formatter, set in the configuration,
+// relative to the directory of the configuration file.
+func resolveTemplateFile(conf *lint.Config, configDir string) {
+	options := conf.Formatters[(&formatter.Template{}).Name()]
+	path, ok := options[formatter.TemplateFileOption].(string)
+	if !ok || filepath.IsAbs(path) {
+		return
+	}
+	options[formatter.TemplateFileOption] = filepath.Join(configDir, path)
+}
+
+// usesTemplate tells if one of the outputs is written by the template formatter.
+func usesTemplate(outputs []lint.OutputConfig) bool {
+	name := (&formatter.Template{}).Name()
+	return slices.ContainsFunc(outputs, func(output lint.OutputConfig) bool { return output.Formatter == name })
+}
+
+// readTemplateFile returns a copy of the configuration where the file of the template formatter, read from AppFs,
+// is replaced by the text of the template.
+func readTemplateFile(conf *lint.Config) (*lint.Config, error) {
+	name := (&formatter.Template{}).Name()
+	options := conf.Formatters[name]
+	path, ok := options[formatter.TemplateFileOption].(string)
+	if _, hasText := options[formatter.TemplateTextOption]; !ok || hasText {
+		// the formatter reports the invalid options
+		return conf, nil
+	}
+
+	content, err := afero.ReadFile(AppFs, path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read the template: %w", err)
+	}
+	withText := *conf
+	withText.Formatters = maps.Clone(conf.Formatters)
+	withText.Formatters[name] = lint.FormatterConfig{
+		formatter.TemplateTextOption: string(content),
+		formatter.TemplateNameOption: filepath.Base(path),
+	}
+	return &withText, nil
+}
+
+// splitBuildTags splits a comma-separated list of build tags.
+func splitBuildTags(tags string) []string {
+	result := []string{}
+	for _, tag := range strings.Split(tags, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag != "" {
+			result = append(result, tag)
+		}
+	}
+
+	return result
+}
+
 // getVersion returns build info (version, commit, date, and builtBy).
 func getVersion(builtBy, date, commit, version string) string {
 	var buildInfo string
@@ -200,6 +363,15 @@ func getVersion(builtBy, date, commit, version string) string {
 	return fmt.Sprintf("Version:\t%s\n%s", version, buildInfo)
 }
 
+// subcommand returns the subcommand named by the first argument, compare or serve, or an empty string if there is none.
+// An existing path named like a subcommand, like a package directory, is linted instead.
+func subcommand(args []string) string {
+	if len(args) == 0 || (args[0] != "compare" && args[0] != "serve") || fileExist(args[0]) {
+		return ""
+	}
+	return args[0]
+}
+
 func fileExist(path string) bool {
 	_, err := AppFs.Stat(path)
 	return err == nil
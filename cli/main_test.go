@@ -1,12 +1,16 @@
 package cli
 
 import (
+	"maps"
 	"os"
 	"path/filepath"
 	"runtime"
+	"slices"
 	"testing"
 
 	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/lint"
 )
 
 func TestMain(m *testing.M) {
@@ -108,3 +112,97 @@ Built		2024-11-15 10:52 UTC by builder
 		t.Errorf("getVersion() = %q, want %q", got, want)
 	}
 }
+
+func TestSubcommand(t *testing.T) {
+	AppFs = afero.NewMemMapFs()
+	t.Cleanup(func() { AppFs = afero.NewMemMapFs() })
+
+	for _, tt := range []struct {
+		args []string
+		want string
+	}{
+		{args: nil, want: ""},
+		{args: []string{"compare", "old.json", "new.json"}, want: "compare"},
+		{args: []string{"serve", "-socket", "revive.sock"}, want: "serve"},
+		{args: []string{"./..."}, want: ""},
+	} {
+		if got := subcommand(tt.args); got != tt.want {
+			t.Errorf("subcommand(%q) = %q, want %q", tt.args, got, tt.want)
+		}
+	}
+
+	// a package directory named like a subcommand is linted
+	if err := AppFs.MkdirAll("serve", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got := subcommand([]string{"serve"}); got != "" {
+		t.Errorf("subcommand of the serve directory = %q, want none", got)
+	}
+}
+
+func TestSplitBuildTags(t *testing.T) {
+	got := splitBuildTags(" integration,, e2e ")
+	want := []string{"integration", "e2e"}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("splitBuildTags() = %q, want %q", got, want)
+	}
+}
+
+func TestSetTemplateFile(t *testing.T) {
+	conf := &lint.Config{Formatters: lint.FormattersConfig{"template": {"template": "inline"}}}
+	setTemplateFile(conf, "report.tmpl")
+
+	want := lint.FormatterConfig{"file": "report.tmpl"}
+	if got := conf.Formatters["template"]; !maps.Equal(got, want) {
+		t.Errorf("got options %v, want %v", got, want)
+	}
+}
+
+func TestReadTemplateFile(t *testing.T) {
+	t.Cleanup(func() { AppFs = afero.NewMemMapFs() })
+	afero.WriteFile(AppFs, filepath.Join("templates", "report.tmpl"), []byte("{{len .Failures}}"), 0o644)
+
+	conf := &lint.Config{}
+	setTemplateFile(conf, filepath.Join("templates", "report.tmpl"))
+	withText, err := readTemplateFile(conf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := lint.FormatterConfig{"template": "{{len .Failures}}", "name": "report.tmpl"}
+	if got := withText.Formatters["template"]; !maps.Equal(got, want) {
+		t.Errorf("got options %v, want %v", got, want)
+	}
+	if got := conf.Formatters["template"]["file"]; got != filepath.Join("templates", "report.tmpl") {
+		t.Errorf("the configuration was modified, got file %v", got)
+	}
+
+	setTemplateFile(conf, "missing.tmpl")
+	if _, err := readTemplateFile(conf); err == nil {
+		t.Error("expected an error for a missing template file")
+	}
+}
+
+func TestResolveTemplateFile(t *testing.T) {
+	for path, want := range map[string]string{
+		"report.tmpl":                   filepath.Join("config", "report.tmpl"),
+		filepath.Join("..", "r.tmpl"):   "r.tmpl",
+		filepath.Join(t.TempDir(), "a"): "",
+	} {
+		conf := &lint.Config{Formatters: lint.FormattersConfig{"template": {"file": path}}}
+		resolveTemplateFile(conf, "config")
+		if want == "" {
+			want = path
+		}
+		if got := conf.Formatters["template"]["file"]; got != want {
+			t.Errorf("%s: got file %v, want %s", path, got, want)
+		}
+	}
+
+	if !usesTemplate([]lint.OutputConfig{{Formatter: "json"}, {Formatter: "template", Path: "report.txt"}}) {
+		t.Error("the template formatter is used")
+	}
+	if usesTemplate([]lint.OutputConfig{{Formatter: "json"}, {}}) {
+		t.Error("the template formatter is not used")
+	}
+}
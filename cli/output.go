@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/revivelib"
+)
+
+// output is a destination of the failures, set by a -formatter flag or an [[output]] section of the configuration.
+type output struct {
+	formatter string
+	file      afero.File // nil for the standard output
+	writer    *outputWriter
+}
+
+// outputWriter writes the output of the formatter, recording if it wrote anything.
+type outputWriter struct {
+	w       io.Writer
+	written bool
+}
+
+func (ow *outputWriter) Write(p []byte) (int, error) {
+	ow.written = ow.written || len(p) > 0
+	return ow.w.Write(p)
+}
+
+// parseOutputFlag parses the value of a -formatter flag, of the form name[:path].
+func parseOutputFlag(value string) lint.OutputConfig {
+	name, path, _ := strings.Cut(value, ":")
+	return lint.OutputConfig{Formatter: name, Path: path}
+}
+
+// openOutputs opens the files of the outputs.
+// Without outputs, the failures are written to the standard output with the default formatter.
+func openOutputs(configs []lint.OutputConfig) ([]*output, error) {
+	if len(configs) == 0 {
+		configs = []lint.OutputConfig{{}}
+	}
+	if err := checkOutputs(configs); err != nil {
+		return nil, err
+	}
+
+	outputs := make([]*output, 0, len(configs))
+	for _, config := range configs {
+		var w io.Writer = os.Stdout
+		var file afero.File
+		if config.Path != "" {
+			f, err := AppFs.Create(config.Path)
+			if err != nil {
+				closeOutputs(outputs)
+				return nil, fmt.Errorf("cannot create the output file: %w", err)
+			}
+			w, file = f, f
+		}
+
+		outputs = append(outputs, &output{
+			formatter: config.Formatter,
+			file:      file,
+			writer:    &outputWriter{w: w},
+		})
+	}
+
+	return outputs, nil
+}
+
+// checkOutputs checks that the outputs write to distinct destinations:
+// the outputs sharing the standard output or a file would mix or overwrite their reports.
+func checkOutputs(configs []lint.OutputConfig) error {
+	stdout := 0
+	paths := map[string]bool{}
+	for _, config := range configs {
+		if config.Path == "" {
+			stdout++
+			if stdout > 1 {
+				return errors.New("several outputs write to the standard output: set the path of the files of all of them but one")
+			}
+			continue
+		}
+
+		path, err := filepath.Abs(config.Path)
+		if err != nil {
+			return err
+		}
+		if paths[path] {
+			return fmt.Errorf("several outputs write to the file %s", config.Path)
+		}
+		paths[path] = true
+	}
+	return nil
+}
+
+// revivelibOutputs returns the outputs as expected by revivelib.
+func revivelibOutputs(outputs []*output) []revivelib.Output {
+	result := make([]revivelib.Output, len(outputs))
+	for i, o := range outputs {
+		result[i] = revivelib.Output{Formatter: o.formatter, Writer: o.writer}
+	}
+
+	return result
+}
+
+// closeOutputs ends the non-empty outputs with a new line, and closes their files.
+func closeOutputs(outputs []*output) error {
+	var err error
+	for _, o := range outputs {
+		if o.writer.written {
+			if _, writeErr := fmt.Fprintln(o.writer.w); writeErr != nil && err == nil {
+				err = writeErr
+			}
+		}
+		if o.file != nil {
+			if closeErr := o.file.Close(); closeErr != nil && err == nil {
+				err = closeErr
+			}
+		}
+	}
+
+	return err
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"io"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestParseOutputFlag(t *testing.T) {
+	tests := []struct {
+		value string
+		want  lint.OutputConfig
+	}{
+		{value: "friendly", want: lint.OutputConfig{Formatter: "friendly"}},
+		{value: "sarif:revive.sarif", want: lint.OutputConfig{Formatter: "sarif", Path: "revive.sarif"}},
+		{value: `checkstyle:C:\reports\revive.xml`, want: lint.OutputConfig{Formatter: "checkstyle", Path: `C:\reports\revive.xml`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := parseOutputFlag(tt.value); got != tt.want {
+				t.Errorf("parseOutputFlag(%q) = %+v, want %+v", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOpenOutputs(t *testing.T) {
+	AppFs = afero.NewMemMapFs()
+	t.Cleanup(func() { AppFs = afero.NewOsFs() })
+
+	outputs, err := openOutputs([]lint.OutputConfig{
+		{Formatter: "json", Path: "revive.json"},
+		{Formatter: "sarif", Path: "revive.sarif"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outputs) != 2 {
+		t.Fatalf("expected 2 outputs, got %d", len(outputs))
+	}
+
+	if _, err := io.WriteString(outputs[0].writer, "[]"); err != nil {
+		t.Fatal(err)
+	}
+	if err := closeOutputs(outputs); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[string]string{"revive.json": "[]\n", "revive.sarif": ""} {
+		got, err := afero.ReadFile(AppFs, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != want {
+			t.Errorf("%s: got %q, want %q", name, got, want)
+		}
+	}
+
+	t.Run("default output", func(t *testing.T) {
+		outputs, err := openOutputs(nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(outputs) != 1 || outputs[0].formatter != "" || outputs[0].file != nil {
+			t.Errorf("expected the standard output with the default formatter, got %+v", outputs)
+		}
+	})
+
+	t.Run("shared destinations", func(t *testing.T) {
+		for name, configs := range map[string][]lint.OutputConfig{
+			"standard output": {{Formatter: "friendly"}, {Formatter: "json"}},
+			"file":            {{Formatter: "json", Path: "revive.json"}, {Formatter: "sarif", Path: "./revive.json"}},
+		} {
+			if outputs, err := openOutputs(configs); err == nil {
+				closeOutputs(outputs)
+				t.Errorf("%s: expected an error for the outputs %+v", name, configs)
+			}
+		}
+	})
+}
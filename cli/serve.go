@@ -0,0 +1,612 @@
+package cli
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"maps"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/internal/ruledoc"
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/revivelib"
+)
+
+// The error codes of JSON-RPC 2.0.
+const (
+	rpcParseError     = -32700
+	rpcInvalidRequest = -32600
+	rpcMethodNotFound = -32601
+	rpcInvalidParams  = -32602
+	rpcServerError    = -32000
+)
+
+// maxRequestSize is the maximum size of a request, overlays included.
+const maxRequestSize = 64 << 20
+
+// rpcRequest is a JSON-RPC 2.0 request; it is a notification, getting no response, if it has no id.
+type rpcRequest struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string { return e.Message }
+
+// newResponse returns the response to the request of the id, with the result or the error of its method.
+func newResponse(id json.RawMessage, result any, err error) rpcResponse {
+	if len(id) == 0 {
+		id = json.RawMessage("null")
+	}
+	response := rpcResponse{JSONRPC: "2.0", ID: id}
+	if err != nil {
+		var rpcErr *rpcError
+		if !errors.As(err, &rpcErr) {
+			rpcErr = &rpcError{Code: rpcServerError, Message: err.Error()}
+		}
+		response.Error = rpcErr
+		return response
+	}
+	response.Result = result
+	return response
+}
+
+// lintParams are the parameters of the lint method.
+type lintParams struct {
+	// Config is the path of the configuration file, the one of the server if empty.
+	Config string `json:"config"`
+	// Patterns are the files and packages to lint, like the arguments of revive.
+	// They are absolute, the server not knowing the working directory of the client.
+	Patterns []string `json:"patterns"`
+	// Exclude are the patterns of the files to skip, like the -exclude flags of revive. They are absolute.
+	Exclude []string `json:"exclude"`
+	// Overlays replace the content of files, by absolute path, like the unsaved buffers of an editor.
+	Overlays map[string]string `json:"overlays"`
+}
+
+// checkPaths checks that the patterns and the paths of the overlays are absolute.
+func (p lintParams) checkPaths() error {
+	paths := slices.Concat(p.Patterns, p.Exclude, slices.Collect(maps.Keys(p.Overlays)))
+	for _, path := range paths {
+		if !filepath.IsAbs(path) {
+			return &rpcError{Code: rpcInvalidParams, Message: fmt.Sprintf("invalid params: relative path %s, the paths must be absolute", path)}
+		}
+	}
+	return nil
+}
+
+// lintResult is the result of the lint method.
+type lintResult struct {
+	// Report is the report of the json formatter.
+	Report         json.RawMessage `json:"report"`
+	ExitCode       int             `json:"exitCode"`
+	LintedFiles    []string        `json:"lintedFiles"`
+	SkippedFiles   []string        `json:"skippedFiles"`
+	GeneratedFiles []string        `json:"generatedFiles"`
+	DurationMs     int64           `json:"durationMs"`
+}
+
+// configParams are the parameters of the methods about a configuration.
+type configParams struct {
+	// Config is the path of the configuration file, the one of the server if empty.
+	Config string `json:"config"`
+}
+
+// ruleInfo describes a rule of a configuration, in the result of the rules method.
+type ruleInfo struct {
+	Name        string             `json:"name"`
+	Enabled     bool               `json:"enabled"`
+	Severity    lint.Severity      `json:"severity"`
+	Arguments   lint.Arguments     `json:"arguments,omitempty"`
+	Exclude     []string           `json:"exclude,omitempty"`
+	Generated   lint.GeneratedMode `json:"generated,omitempty"`
+	Description string             `json:"description,omitempty"`
+}
+
+// reloadResult is the result of the reloadConfig method.
+type reloadResult struct {
+	Config string `json:"config"`
+	Rules  int    `json:"rules"`
+}
+
+// serverStats is the result of the stats method.
+type serverStats struct {
+	StartTime time.Time        `json:"startTime"`
+	Requests  map[string]int64 `json:"requests"`
+	Configs   []configStats    `json:"configs"`
+}
+
+type configStats struct {
+	Config         string    `json:"config"`
+	LoadedAt       time.Time `json:"loadedAt"`
+	Runs           int64     `json:"runs"`
+	CachedPackages int       `json:"cachedPackages"`
+}
+
+// server answers the JSON-RPC requests of the serve command. It lints with a revive instance per configuration file,
+// keeping the packages it linted in memory until the configuration is reloaded.
+// The requests are handled concurrently, the ones of different configurations sharing nothing.
+type server struct {
+	// defaultConfig is the path of the configuration of the requests setting none, the default configuration if empty
+	defaultConfig string
+	extraRules    []revivelib.ExtraRule
+	// fs is where the changes of the linted files are detected
+	fs        afero.Fs
+	startTime time.Time
+
+	mu       sync.Mutex
+	engines  map[string]*engine
+	requests map[string]int64
+}
+
+// engine lints with a configuration.
+type engine struct {
+	configPath string
+	conf       *lint.Config
+	revive     *revivelib.Revive
+	cache      *lint.Cache
+	loadedAt   time.Time
+	runs       atomic.Int64
+
+	// filesMu guards files
+	filesMu sync.Mutex
+	// files is the state of the files of the last runs, to lint their packages again once they change
+	files map[string]fileState
+}
+
+func newServer(defaultConfig string, extraRules []revivelib.ExtraRule) *server {
+	return &server{
+		defaultConfig: defaultConfig,
+		extraRules:    extraRules,
+		fs:            afero.NewOsFs(),
+		startTime:     time.Now(),
+		engines:       map[string]*engine{},
+		requests:      map[string]int64{},
+	}
+}
+
+// configKey returns the path of the configuration of a request, absolute for the engines to be shared by the clients.
+func (s *server) configKey(path string) (string, error) {
+	if path == "" {
+		path = s.defaultConfig
+	}
+	if path == "" {
+		return "", nil
+	}
+	return filepath.Abs(path)
+}
+
+// engine returns the engine of the configuration, loading it if needed.
+func (s *server) engine(configPath string) (*engine, error) {
+	key, err := s.configKey(configPath)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if e, ok := s.engines[key]; ok {
+		return e, nil
+	}
+	e, err := s.newEngine(key)
+	if err != nil {
+		return nil, err
+	}
+	s.engines[key] = e
+	return e, nil
+}
+
+func (s *server) newEngine(configPath string) (*engine, error) {
+	conf, err := config.GetConfig(configPath)
+	if err != nil {
+		return nil, err
+	}
+	cache := lint.NewCache()
+	revive, err := revivelib.New(conf, revivelib.WithExtraRules(s.extraRules...), revivelib.WithCache(cache))
+	if err != nil {
+		return nil, err
+	}
+	return &engine{
+		configPath: configPath,
+		conf:       conf,
+		revive:     revive,
+		cache:      cache,
+		loadedAt:   time.Now(),
+		files:      map[string]fileState{},
+	}, nil
+}
+
+// handle calls the method of a request, and returns its result.
+func (s *server) handle(ctx context.Context, method string, params json.RawMessage) (any, error) {
+	s.mu.Lock()
+	s.requests[method]++
+	s.mu.Unlock()
+
+	switch method {
+	case "lint":
+		var p lintParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.lint(ctx, p)
+	case "reloadConfig":
+		var p configParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.reloadConfig(p)
+	case "rules":
+		var p configParams
+		if err := decodeParams(params, &p); err != nil {
+			return nil, err
+		}
+		return s.rules(p)
+	case "stats":
+		return s.stats(), nil
+	default:
+		return nil, &rpcError{Code: rpcMethodNotFound, Message: "method not found: " + method}
+	}
+}
+
+// decodeParams decodes the parameters of a request, reporting the unknown ones.
+func decodeParams(params json.RawMessage, v any) error {
+	if len(params) == 0 || string(params) == "null" {
+		return nil
+	}
+	decoder := json.NewDecoder(bytes.NewReader(params))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(v); err != nil {
+		return &rpcError{Code: rpcInvalidParams, Message: "invalid params: " + err.Error()}
+	}
+	return nil
+}
+
+// lint lints the patterns of the request. The packages whose files did not change since they were linted,
+// and that have no overlaid file, are not linted again.
+func (s *server) lint(ctx context.Context, p lintParams) (*lintResult, error) {
+	if len(p.Patterns) == 0 {
+		return nil, &rpcError{Code: rpcInvalidParams, Message: "invalid params: no pattern to lint"}
+	}
+	if err := p.checkPaths(); err != nil {
+		return nil, err
+	}
+	e, err := s.engine(p.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns := make([]*revivelib.LintPattern, 0, len(p.Patterns)+len(p.Exclude))
+	for _, pattern := range p.Patterns {
+		patterns = append(patterns, revivelib.Include(pattern))
+	}
+	for _, pattern := range p.Exclude {
+		patterns = append(patterns, revivelib.Exclude(pattern))
+	}
+
+	// the packages of the files that changed are linted again, the ones of the overlays without the cache
+	e.invalidateChanged(s.fs, p.Patterns)
+	revive := e.revive
+	if len(p.Overlays) > 0 {
+		overlays := make(map[string][]byte, len(p.Overlays))
+		for name, content := range p.Overlays {
+			overlays[name] = []byte(content)
+		}
+		if revive, err = e.revive.Overlay(overlays); err != nil {
+			return nil, err
+		}
+	}
+
+	e.runs.Add(1)
+	result, err := revive.Run(ctx, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	report, err := jsonReport(*e.conf, result.Failures)
+	if err != nil {
+		return nil, err
+	}
+
+	return &lintResult{
+		Report:         report,
+		ExitCode:       result.ExitCode,
+		LintedFiles:    result.LintedFiles,
+		SkippedFiles:   result.SkippedFiles,
+		GeneratedFiles: result.GeneratedFiles,
+		DurationMs:     result.Duration.Milliseconds(),
+	}, nil
+}
+
+// invalidateChanged drops from the cache the packages of the files of the patterns that changed since the last run,
+// and the ones of the removed files. The packages of the other patterns are kept for the next requests.
+func (e *engine) invalidateChanged(fsys afero.Fs, patterns []string) {
+	current := watchedFiles(fsys, patterns, nil, nil)
+
+	e.filesMu.Lock()
+	defer e.filesMu.Unlock()
+	var changed []string
+	for path, state := range current {
+		if previous, ok := e.files[path]; ok && (!previous.modTime.Equal(state.modTime) || previous.size != state.size) {
+			changed = append(changed, path)
+		}
+		e.files[path] = state
+	}
+	for path := range e.files {
+		if _, ok := current[path]; ok {
+			continue
+		}
+		if _, err := fsys.Stat(path); errors.Is(err, fs.ErrNotExist) {
+			changed = append(changed, path)
+			delete(e.files, path)
+		}
+	}
+	e.cache.Invalidate(changed...)
+}
+
+// jsonReport returns the report of the json formatter for the failures.
+func jsonReport(conf lint.Config, failures []lint.Failure) (json.RawMessage, error) {
+	f, err := config.GetFormatter("json")
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	fw, err := lint.AsStreamFormatter(f).Begin(&buf, conf)
+	if err != nil {
+		return nil, err
+	}
+	for _, failure := range failures {
+		if err := fw.Failure(failure); err != nil {
+			return nil, err
+		}
+	}
+	if err := fw.End(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+// reloadConfig reads the configuration file again, dropping the packages linted with the previous configuration.
+// The previous configuration is kept if the new one is invalid.
+func (s *server) reloadConfig(p configParams) (*reloadResult, error) {
+	key, err := s.configKey(p.Config)
+	if err != nil {
+		return nil, err
+	}
+	e, err := s.newEngine(key)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	s.engines[key] = e
+	s.mu.Unlock()
+
+	rules := 0
+	for _, ruleConfig := range e.conf.Rules {
+		if !ruleConfig.Disabled {
+			rules++
+		}
+	}
+	return &reloadResult{Config: key, Rules: rules}, nil
+}
+
+// rules returns the rules of the configuration, sorted by name.
+func (s *server) rules(p configParams) ([]ruleInfo, error) {
+	e, err := s.engine(p.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]ruleInfo, 0, len(e.conf.Rules))
+	for _, name := range slices.Sorted(maps.Keys(e.conf.Rules)) {
+		ruleConfig := e.conf.Rules[name]
+		severity := lint.Severity(lint.SeverityWarning)
+		if ruleConfig.Severity == lint.SeverityError {
+			severity = lint.SeverityError
+		}
+		info := ruleInfo{
+			Name:      name,
+			Enabled:   !ruleConfig.Disabled,
+			Severity:  severity,
+			Arguments: ruleConfig.Arguments,
+			Exclude:   ruleConfig.Exclude,
+			Generated: ruleConfig.Generated,
+		}
+		if description, ok := ruledoc.Of(name); ok {
+			info.Description = description.Short
+		}
+		rules = append(rules, info)
+	}
+	return rules, nil
+}
+
+// stats returns the number of requests by method, and the statistics of the loaded configurations.
+func (s *server) stats() *serverStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := &serverStats{StartTime: s.startTime, Requests: maps.Clone(s.requests), Configs: []configStats{}}
+	for _, key := range slices.Sorted(maps.Keys(s.engines)) {
+		e := s.engines[key]
+		stats.Configs = append(stats.Configs, configStats{
+			Config:         e.configPath,
+			LoadedAt:       e.loadedAt,
+			Runs:           e.runs.Load(),
+			CachedPackages: e.cache.Len(),
+		})
+	}
+	return stats
+}
+
+// serveConn answers the requests of a connection, a JSON object per line, until it is closed or the context is done.
+// The requests are handled concurrently, their responses being written as they complete.
+func (s *server) serveConn(ctx context.Context, conn io.ReadWriter) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		writeMu sync.Mutex
+		wg      sync.WaitGroup
+	)
+	encoder := json.NewEncoder(conn)
+	respond := func(response rpcResponse) {
+		writeMu.Lock()
+		defer writeMu.Unlock()
+		// a client that went away gets no response
+		_ = encoder.Encode(response)
+	}
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(nil, maxRequestSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		if line[0] == '[' {
+			respond(newResponse(nil, nil, &rpcError{Code: rpcInvalidRequest, Message: "batch requests are not supported"}))
+			continue
+		}
+
+		var request rpcRequest
+		if err := json.Unmarshal(line, &request); err != nil {
+			respond(newResponse(nil, nil, &rpcError{Code: rpcParseError, Message: "parse error: " + err.Error()}))
+			continue
+		}
+		if request.JSONRPC != "2.0" || request.Method == "" {
+			respond(newResponse(request.ID, nil, &rpcError{Code: rpcInvalidRequest, Message: `invalid request: expected "jsonrpc": "2.0" and a method`}))
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			result, err := s.handle(ctx, request.Method, request.Params)
+			if len(request.ID) > 0 {
+				respond(newResponse(request.ID, result, err))
+			}
+		}()
+	}
+	if err := scanner.Err(); err != nil {
+		respond(newResponse(nil, nil, &rpcError{Code: rpcParseError, Message: "reading the request: " + err.Error()}))
+	}
+	wg.Wait()
+}
+
+// listenSocket listens on the Unix domain socket at path, replacing the socket of a server that is not running anymore.
+func listenSocket(path string) (net.Listener, error) {
+	if _, err := os.Stat(path); err == nil {
+		if conn, err := net.Dial("unix", path); err == nil {
+			conn.Close()
+			return nil, fmt.Errorf("a server is already listening on %s", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	// only the user can send requests to the server
+	if err := os.Chmod(path, 0o600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+	return listener, nil
+}
+
+// runServe runs the serve command, answering the JSON-RPC requests sent to a Unix domain socket
+// until interrupted, and returns the exit code of the command.
+func runServe(args []string, extraRules []revivelib.ExtraRule, stderr io.Writer) int {
+	flags := flag.NewFlagSet("serve", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: revive serve -socket path [-config revive.toml]")
+		fmt.Fprintln(stderr, "\nAnswers JSON-RPC 2.0 requests sent to a Unix domain socket, a JSON object per line,")
+		fmt.Fprintln(stderr, "with the methods lint, reloadConfig, rules and stats.")
+		fmt.Fprintln(stderr)
+		flags.PrintDefaults()
+	}
+	socket := flags.String("socket", "", "path of the Unix domain socket to listen on")
+	configFile := flags.String("config", buildDefaultConfigPath(), "path of the configuration of the requests setting none, defaults to $XDG_CONFIG_HOME/revive.toml or $HOME/revive.toml, if present")
+	if err := flags.Parse(args); err != nil {
+		return 1
+	}
+	if *socket == "" || flags.NArg() > 0 {
+		flags.Usage()
+		return 1
+	}
+
+	s := newServer(*configFile, extraRules)
+	if _, err := s.engine(""); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	listener, err := listenSocket(*socket)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		listener.Close()
+	}()
+
+	fmt.Fprintf(stderr, "Listening on %s\n", *socket)
+	var wg sync.WaitGroup
+	defer wg.Wait()
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil {
+				return 0
+			}
+			fmt.Fprintf(stderr, "accepting a connection: %v\n", err)
+			return 1
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// the connections are closed when the server stops
+			stopClosing := context.AfterFunc(ctx, func() { conn.Close() })
+			defer stopClosing()
+			defer conn.Close()
+			s.serveConn(ctx, conn)
+		}()
+	}
+}
@@ -0,0 +1,346 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/revivelib"
+)
+
+// rpcClient sends requests to a server through an in-memory connection.
+type rpcClient struct {
+	t       *testing.T
+	conn    net.Conn
+	scanner *bufio.Scanner
+}
+
+func newRPCClient(t *testing.T, s *server) *rpcClient {
+	t.Helper()
+	client, conn := net.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		s.serveConn(context.Background(), conn)
+		conn.Close()
+	}()
+	t.Cleanup(func() {
+		client.Close()
+		<-done
+	})
+	return &rpcClient{t: t, conn: client, scanner: bufio.NewScanner(client)}
+}
+
+func (c *rpcClient) send(line string) {
+	c.t.Helper()
+	if _, err := c.conn.Write([]byte(line + "\n")); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+func (c *rpcClient) receive() map[string]json.RawMessage {
+	c.t.Helper()
+	if !c.scanner.Scan() {
+		c.t.Fatalf("no response: %v", c.scanner.Err())
+	}
+	var response map[string]json.RawMessage
+	if err := json.Unmarshal(c.scanner.Bytes(), &response); err != nil {
+		c.t.Fatal(err)
+	}
+	return response
+}
+
+// call sends a request and decodes the result of its response into result, failing on an error response.
+func (c *rpcClient) call(method string, params any, result any) {
+	c.t.Helper()
+	request, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": 1, "method": method, "params": params})
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	c.send(string(request))
+	response := c.receive()
+	if response["error"] != nil {
+		c.t.Fatalf("%s: got error %s", method, response["error"])
+	}
+	if err := json.Unmarshal(response["result"], result); err != nil {
+		c.t.Fatal(err)
+	}
+}
+
+// writeServeFiles writes the files in a temporary directory, and returns the directory.
+func writeServeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+// reportRules returns the rules of the failures of a report of the json formatter.
+func reportRules(t *testing.T, report json.RawMessage) []string {
+	t.Helper()
+	var r struct {
+		Failures []struct {
+			Rule string `json:"rule"`
+		} `json:"failures"`
+	}
+	if err := json.Unmarshal(report, &r); err != nil {
+		t.Fatal(err)
+	}
+	var rules []string
+	for _, failure := range r.Failures {
+		rules = append(rules, failure.Rule)
+	}
+	return rules
+}
+
+func TestServerLint(t *testing.T) {
+	dir := writeServeFiles(t, map[string]string{
+		"go.mod":        "module example.com/a\n\ngo 1.22\n",
+		"a.go":          "package a\n\nfunc F() {}\n",
+		"exported.toml": "[rule.exported]\n",
+		"errors.toml":   "[rule.error-strings]\n",
+	})
+	client := newRPCClient(t, newServer(filepath.Join(dir, "exported.toml"), nil))
+	patterns := []string{filepath.Join(dir, "...")}
+
+	for range 2 {
+		var result lintResult
+		client.call("lint", lintParams{Patterns: patterns}, &result)
+		if rules := reportRules(t, result.Report); len(rules) != 1 || rules[0] != "exported" {
+			t.Fatalf("got failures of %v, want one of exported", rules)
+		}
+		if len(result.LintedFiles) != 1 {
+			t.Fatalf("got linted files %v, want a.go", result.LintedFiles)
+		}
+	}
+
+	// the overlay is only seen by its request
+	var result lintResult
+	overlays := map[string]string{filepath.Join(dir, "a.go"): "package a\n\n// F does nothing.\nfunc F() {}\n"}
+	client.call("lint", lintParams{Patterns: patterns, Overlays: overlays}, &result)
+	if rules := reportRules(t, result.Report); len(rules) != 0 {
+		t.Fatalf("got failures of %v with the overlay, want none", rules)
+	}
+	client.call("lint", lintParams{Patterns: patterns}, &result)
+	if rules := reportRules(t, result.Report); len(rules) != 1 {
+		t.Fatalf("got failures of %v without the overlay, want one", rules)
+	}
+
+	// the changes of the files are detected
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte("package a\n\nfunc F() {}\n\nfunc G() {}\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	client.call("lint", lintParams{Patterns: patterns}, &result)
+	if rules := reportRules(t, result.Report); len(rules) != 2 {
+		t.Fatalf("got failures of %v once the file changed, want two", rules)
+	}
+
+	// the configurations are isolated
+	client.call("lint", lintParams{Config: filepath.Join(dir, "errors.toml"), Patterns: patterns}, &result)
+	if rules := reportRules(t, result.Report); len(rules) != 0 {
+		t.Fatalf("got failures of %v with the other configuration, want none", rules)
+	}
+
+	var stats serverStats
+	client.call("stats", nil, &stats)
+	if stats.Requests["lint"] != 6 || len(stats.Configs) != 2 {
+		t.Fatalf("unexpected stats %+v", stats)
+	}
+	for _, config := range stats.Configs {
+		if strings.HasSuffix(config.Config, "exported.toml") && (config.Runs != 5 || config.CachedPackages != 1) {
+			t.Errorf("unexpected stats of %s: %+v", config.Config, config)
+		}
+	}
+}
+
+// countingRule counts the files it is applied to.
+type countingRule struct {
+	applied atomic.Int64
+}
+
+func (*countingRule) Name() string { return "counting" }
+
+func (r *countingRule) Apply(*lint.File, lint.Arguments) []lint.Failure {
+	r.applied.Add(1)
+	return nil
+}
+
+func TestServerKeepsPackages(t *testing.T) {
+	dir := writeServeFiles(t, map[string]string{"go.mod": "module example.com/m\n\ngo 1.22\n"})
+	for _, name := range []string{"a", "b"} {
+		if err := os.Mkdir(filepath.Join(dir, name), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name, name+".go"), []byte("package "+name+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rule := &countingRule{}
+	client := newRPCClient(t, newServer("", []revivelib.ExtraRule{revivelib.NewExtraRule(rule, lint.RuleConfig{})}))
+
+	// the packages of a request are kept while the ones of other requests are linted
+	for i, step := range []struct {
+		pkg     string
+		applied int64
+	}{
+		{pkg: "a", applied: 1},
+		{pkg: "b", applied: 1},
+		{pkg: "a", applied: 0},
+		{pkg: "b", applied: 0},
+	} {
+		rule.applied.Store(0)
+		var result lintResult
+		client.call("lint", lintParams{Patterns: []string{filepath.Join(dir, step.pkg)}}, &result)
+		if got := rule.applied.Load(); got != step.applied {
+			t.Errorf("step %d: linting %s applied the rule to %d files, want %d", i, step.pkg, got, step.applied)
+		}
+	}
+
+	// only the packages of the overlays are linted again, without replacing the cached ones
+	patterns := []string{filepath.Join(dir, "a"), filepath.Join(dir, "b")}
+	overlays := map[string]string{filepath.Join(dir, "a", "a.go"): "package a\n\nvar x = 1\n"}
+	for i, step := range []struct {
+		overlays map[string]string
+		applied  int64
+	}{
+		{overlays: overlays, applied: 1},
+		{overlays: nil, applied: 0},
+	} {
+		rule.applied.Store(0)
+		var result lintResult
+		client.call("lint", lintParams{Patterns: patterns, Overlays: step.overlays}, &result)
+		if got := rule.applied.Load(); got != step.applied {
+			t.Errorf("overlay step %d: the rule was applied to %d files, want %d", i, got, step.applied)
+		}
+	}
+
+	// the packages of the removed files are dropped
+	if err := os.Remove(filepath.Join(dir, "b", "b.go")); err != nil {
+		t.Fatal(err)
+	}
+	var result lintResult
+	client.call("lint", lintParams{Patterns: []string{filepath.Join(dir, "a")}}, &result)
+	var stats serverStats
+	client.call("stats", nil, &stats)
+	if got := stats.Configs[0].CachedPackages; got != 1 {
+		t.Errorf("got %d cached packages, want 1", got)
+	}
+}
+
+func TestServerRules(t *testing.T) {
+	dir := writeServeFiles(t, map[string]string{
+		"revive.toml": "[rule.exported]\nseverity = \"error\"\n[rule.unhandled-error]\nDisabled = true\ngenerated = \"lint\"\n",
+	})
+	configPath := filepath.Join(dir, "revive.toml")
+	client := newRPCClient(t, newServer(configPath, nil))
+
+	var rules []ruleInfo
+	client.call("rules", configParams{}, &rules)
+	if len(rules) != 2 {
+		t.Fatalf("got rules %+v, want exported and unhandled-error", rules)
+	}
+	if r := rules[0]; r.Name != "exported" || !r.Enabled || r.Severity != "error" || r.Description == "" {
+		t.Errorf("unexpected rule %+v", r)
+	}
+	if r := rules[1]; r.Name != "unhandled-error" || r.Enabled || r.Severity != "warning" || r.Generated != "lint" {
+		t.Errorf("unexpected rule %+v", r)
+	}
+
+	if err := os.WriteFile(configPath, []byte("[rule.exported]\n[rule.var-naming]\n[rule.errorf]\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var reloaded reloadResult
+	client.call("reloadConfig", configParams{}, &reloaded)
+	if reloaded.Rules != 3 {
+		t.Errorf("got %d rules once reloaded, want 3", reloaded.Rules)
+	}
+	client.call("rules", configParams{}, &rules)
+	if len(rules) != 3 {
+		t.Errorf("got rules %+v once reloaded, want 3", rules)
+	}
+}
+
+func TestServerErrors(t *testing.T) {
+	client := newRPCClient(t, newServer("", nil))
+	absPattern := filepath.ToSlash(filepath.Join(t.TempDir(), "..."))
+
+	for _, tt := range []struct {
+		request string
+		code    int
+	}{
+		{request: `{"jsonrpc":"2.0","id":1,"method":"unknown"}`, code: rpcMethodNotFound},
+		{request: `{"jsonrpc":"2.0","id":2,"method":"lint","params":{"patterns":[],"unknown":1}}`, code: rpcInvalidParams},
+		{request: `{"jsonrpc":"2.0","id":3,"method":"lint","params":{}}`, code: rpcInvalidParams},
+		{request: `{"jsonrpc":"2.0","id":3,"method":"lint","params":{"patterns":["./..."]}}`, code: rpcInvalidParams},
+		{request: `{"jsonrpc":"2.0","id":3,"method":"lint","params":{"patterns":["` + absPattern + `"],"exclude":["vendor/..."]}}`, code: rpcInvalidParams},
+		{request: `{"jsonrpc":"2.0","id":3,"method":"lint","params":{"patterns":["` + absPattern + `"],"overlays":{"main.go":""}}}`, code: rpcInvalidParams},
+		{request: `{"jsonrpc":"2.0","id":4,"method":"rules","params":{"config":"missing.toml"}}`, code: rpcServerError},
+		{request: `{"id":5,"method":"stats"}`, code: rpcInvalidRequest},
+		{request: `[{"jsonrpc":"2.0","id":6,"method":"stats"}]`, code: rpcInvalidRequest},
+		{request: `{"jsonrpc":`, code: rpcParseError},
+	} {
+		client.send(tt.request)
+		response := client.receive()
+		var rpcErr rpcError
+		if err := json.Unmarshal(response["error"], &rpcErr); err != nil {
+			t.Fatalf("%s: no error in response %v", tt.request, response)
+		}
+		if rpcErr.Code != tt.code {
+			t.Errorf("%s: got error %+v, want code %d", tt.request, rpcErr, tt.code)
+		}
+	}
+
+	// a notification gets no response: the next response is the one of the request
+	client.send(`{"jsonrpc":"2.0","method":"stats"}`)
+	client.send(`{"jsonrpc":"2.0","id":"last","method":"stats"}`)
+	if id := string(client.receive()["id"]); id != `"last"` {
+		t.Errorf("got response of %s, want the one of the last request", id)
+	}
+}
+
+func TestServerConcurrentRequests(t *testing.T) {
+	dir := writeServeFiles(t, map[string]string{
+		"a.go":          "package a\n\nfunc F() error { return nil }\n\nfunc g() { F() }\n",
+		"exported.toml": "[rule.exported]\n",
+		"errors.toml":   "[rule.unhandled-error]\n",
+	})
+	client := newRPCClient(t, newServer("", nil))
+
+	want := map[string]string{}
+	for i, config := range []string{"exported.toml", "errors.toml", "exported.toml", "errors.toml"} {
+		request, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": i, "method": "lint", "params": lintParams{
+			Config:   filepath.Join(dir, config),
+			Patterns: []string{filepath.Join(dir, "a.go")},
+		}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		client.send(string(request))
+		want[strconv.Itoa(i)] = strings.TrimSuffix(config, ".toml")
+	}
+
+	for range want {
+		response := client.receive()
+		var result lintResult
+		if err := json.Unmarshal(response["result"], &result); err != nil {
+			t.Fatalf("unexpected response %v", response)
+		}
+		rules := reportRules(t, result.Report)
+		id := string(response["id"])
+		if wantRule := map[string]string{"exported": "exported", "errors": "unhandled-error"}[want[id]]; len(rules) != 1 || rules[0] != wantRule {
+			t.Errorf("request %s: got failures of %v, want one of %s", id, rules, wantRule)
+		}
+	}
+}
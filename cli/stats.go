@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	goversion "github.com/hashicorp/go-version"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// runStats gathers the statistics printed with the -stats flag.
+type runStats struct {
+	mu        sync.Mutex
+	linted    int
+	generated int
+	invalid   int
+	// generatedLinted counts the generated files linted by the rules set to lint generated code
+	generatedLinted int
+	goVersions      map[string]int
+}
+
+func newRunStats() *runStats {
+	return &runStats{
+		goVersions: map[string]int{},
+	}
+}
+
+// add accounts for a file handled by the linter.
+func (s *runStats) add(report lint.FileReport) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case report.Generated:
+		s.generated++
+	case report.Invalid:
+		s.invalid++
+	default:
+		s.linted++
+		s.goVersions[report.GoVersion]++
+		if report.GeneratedLinted {
+			s.generatedLinted++
+		}
+	}
+}
+
+// print writes the statistics of a run that took the given time,
+// the Go language versions being sorted from the most recent.
+func (s *runStats) print(w io.Writer, elapsed time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	fmt.Fprintf(w, "Linted %d files in %v (skipped %d generated and %d invalid files)\n", s.linted, elapsed.Round(time.Millisecond), s.generated, s.invalid)
+	if s.generatedLinted > 0 {
+		fmt.Fprintf(w, "Linted %d generated files with the rules set to lint generated code only\n", s.generatedLinted)
+	}
+
+	versions := slices.SortedFunc(maps.Keys(s.goVersions), func(a, b string) int {
+		va, errA := goversion.NewVersion(a)
+		vb, errB := goversion.NewVersion(b)
+		if errA != nil || errB != nil {
+			return strings.Compare(a, b)
+		}
+		return vb.Compare(va)
+	})
+	counts := make([]string, len(versions))
+	for i, v := range versions {
+		counts[i] = fmt.Sprintf("go%s (%d files)", v, s.goVersions[v])
+	}
+	if len(counts) > 0 {
+		fmt.Fprintf(w, "Go language versions: %s\n", strings.Join(counts, ", "))
+	}
+}
+
+// printExcludeRules writes the number of failures excluded by each [[exclude-rule]] entry,
+// for the entries matching no failure to be removed.
+func printExcludeRules(w io.Writer, excludeRules []lint.ExcludeRule) {
+	if len(excludeRules) == 0 {
+		return
+	}
+
+	fmt.Fprintln(w, "Exclude rules:")
+	for i := range excludeRules {
+		excludeRule := &excludeRules[i]
+		matches := "unused"
+		if count := excludeRule.Matches(); count > 0 {
+			matches = fmt.Sprintf("excluded %d failures", count)
+		}
+		reason := ""
+		if excludeRule.Reason != "" {
+			reason = fmt.Sprintf(" (%s)", excludeRule.Reason)
+		}
+		fmt.Fprintf(w, "  #%d %s%s: %s\n", i+1, excludeRule, reason, matches)
+	}
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestRunStats(t *testing.T) {
+	stats := newRunStats()
+	for _, report := range []lint.FileReport{
+		{Name: "a.go", GoVersion: "1.9"},
+		{Name: "b.go", GoVersion: "1.22"},
+		{Name: "c.go", GoVersion: "1.22"},
+		{Name: "d.go", Generated: true},
+		{Name: "e.go", Invalid: true},
+		{Name: "f.go", GoVersion: "1.22", GeneratedLinted: true},
+	} {
+		stats.add(report)
+	}
+
+	var out strings.Builder
+	stats.print(&out, 1500*time.Millisecond)
+
+	want := "Linted 4 files in 1.5s (skipped 1 generated and 1 invalid files)\n" +
+		"Linted 1 generated files with the rules set to lint generated code only\n" +
+		"Go language versions: go1.22 (3 files), go1.9 (1 files)\n"
+	if got := out.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestPrintExcludeRules(t *testing.T) {
+	excludeRules := []lint.ExcludeRule{
+		{Rule: "add-constant", Symbol: "^Test", Reason: "tests use literal values"},
+		{Category: "naming"},
+	}
+	for i := range excludeRules {
+		if err := excludeRules[i].Initialize(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out strings.Builder
+	printExcludeRules(&out, excludeRules)
+
+	want := "Exclude rules:\n" +
+		"  #1 rule=add-constant symbol=^Test (tests use literal values): unused\n" +
+		"  #2 category=naming: unused\n"
+	if got := out.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/revivelib"
+)
+
+// watchInterval is the time between two checks of the watched files for changes.
+const watchInterval = 500 * time.Millisecond
+
+// fileState is the state of a watched file, telling if it changed.
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// runWatch lints the packages of the command line, then lints them again every time the watched files change,
+// until the process is interrupted. The packages whose files did not change are kept in memory with their failures;
+// all the packages are linted again when the configuration, the go.mod and go.work files or the ignore files change.
+// An invalid configuration is reported, and the packages are linted again once it is fixed.
+func runWatch(conf *lint.Config, extraRules []revivelib.ExtraRule) {
+	patterns := flag.Args()
+	configFiles := slices.DeleteFunc([]string{configPath, templatePath}, func(path string) bool { return path == "" })
+	ignoreFiles := []string{reviveIgnoreFile}
+	if gitIgnore {
+		ignoreFiles = append(ignoreFiles, gitIgnoreFile)
+	}
+	watched := func() map[string]fileState {
+		// the CODEOWNERS file only changes the owners of the failures, it is watched for them to be written again
+		codeOwners := cmp.Or(codeOwnersPath, revivelib.FindCodeOwners(AppFs))
+		return watchedFiles(AppFs, patterns, ignoreFiles, append(slices.Clone(configFiles), codeOwners))
+	}
+
+	cache := newWatchCache()
+	state := watched()
+	for {
+		if conf != nil {
+			stats := newRunStats()
+			start := time.Now()
+			if _, err := runLint(conf, cache, stats, extraRules); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			if statsFlag {
+				stats.print(os.Stderr, time.Since(start))
+			}
+		}
+		fmt.Fprintln(os.Stderr, "Watching for changes...")
+
+		var changed []string
+		for len(changed) == 0 {
+			time.Sleep(watchInterval)
+			current := watched()
+			changed = changedFiles(state, current)
+			state = current
+		}
+		fmt.Fprintf(os.Stderr, "\n%s changed, linting again\n", describeChanges(changed))
+
+		if slices.ContainsFunc(changed, func(path string) bool { return slices.Contains(configFiles, path) }) {
+			var err error
+			if conf, err = loadConfig(); err != nil {
+				fmt.Fprintln(os.Stderr, err)
+			}
+			cache = newWatchCache()
+			continue
+		}
+		if slices.ContainsFunc(changed, func(path string) bool { return resetsCache(path, ignoreFiles) }) {
+			cache = newWatchCache()
+			continue
+		}
+		cache.Invalidate(changed...)
+	}
+}
+
+const (
+	// reviveIgnoreFile and gitIgnoreFile are the names of the ignore files of revive.
+	reviveIgnoreFile = ".reviveignore"
+	gitIgnoreFile    = ".gitignore"
+)
+
+// moduleFiles are the names of the files of the modules, setting the import paths and the language versions of their packages.
+var moduleFiles = []string{"go.mod", "go.work"}
+
+// resetsCache tells if a change of the watched file changes how all the packages are linted:
+// the files of the modules and the ignore files.
+func resetsCache(path string, ignoreFiles []string) bool {
+	name := filepath.Base(path)
+	return slices.Contains(moduleFiles, name) || slices.Contains(ignoreFiles, name)
+}
+
+// newWatchCache returns the cache of runWatch, dropping the packages whose files were removed.
+func newWatchCache() *lint.Cache {
+	cache := lint.NewCache()
+	cache.DropUnused()
+	return cache
+}
+
+// watchedFiles returns the state of the files watched by runWatch: the Go files of the packages of the patterns,
+// the go.mod and go.work files of their modules, the ignore files of their directories and their parents,
+// and the extra files, like the configuration.
+// The directories skipped by the go command, like testdata and vendor, are not watched.
+func watchedFiles(fsys afero.Fs, patterns, ignoreFiles, extraFiles []string) map[string]fileState {
+	files := map[string]fileState{}
+	add := func(path string, info fs.FileInfo) {
+		files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+	}
+	addFile := func(path string) {
+		if info, err := fsys.Stat(path); err == nil && !info.IsDir() {
+			add(path, info)
+		}
+	}
+
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+	for _, pattern := range patterns {
+		root, recursive := strings.CutSuffix(filepath.ToSlash(pattern), "...")
+		root = filepath.Clean(filepath.FromSlash(cmp.Or(strings.TrimSuffix(root, "/"), ".")))
+		if info, err := fsys.Stat(root); err == nil && !info.IsDir() {
+			add(root, info)
+			root = filepath.Dir(root)
+		} else {
+			_ = afero.Walk(fsys, root, func(path string, info fs.FileInfo, err error) error {
+				switch {
+				case err != nil:
+					return nil
+				case info.IsDir() && path != root && (!recursive || isSkippedDir(info.Name())):
+					return filepath.SkipDir
+				case !info.IsDir() && (strings.HasSuffix(path, ".go") || slices.Contains(ignoreFiles, info.Name())):
+					add(path, info)
+				}
+				return nil
+			})
+		}
+
+		// the module of the root is the closest one from it up, and the ignore files of the parents apply to it
+		for dir := root; ; dir = filepath.Join(dir, "..") {
+			for _, name := range slices.Concat(moduleFiles, ignoreFiles) {
+				addFile(filepath.Join(dir, name))
+			}
+			abs, err := filepath.Abs(dir)
+			if err != nil || filepath.Dir(abs) == abs {
+				break
+			}
+		}
+	}
+
+	for _, path := range extraFiles {
+		addFile(path)
+	}
+	return files
+}
+
+// isSkippedDir tells if the go command skips the directory of the given name when matching the ./... patterns.
+func isSkippedDir(name string) bool {
+	return name == "testdata" || name == "vendor" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+}
+
+// changedFiles returns the sorted paths of the files added, removed or modified from a state of the watched files to another.
+func changedFiles(previous, current map[string]fileState) []string {
+	var changed []string
+	for path, state := range current {
+		if previousState, ok := previous[path]; !ok || !previousState.modTime.Equal(state.modTime) || previousState.size != state.size {
+			changed = append(changed, path)
+		}
+	}
+	for path := range previous {
+		if _, ok := current[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	slices.Sort(changed)
+	return changed
+}
+
+// describeChanges describes the changed files, naming them if there are few.
+func describeChanges(changed []string) string {
+	const maxNamed = 3
+	if len(changed) > maxNamed {
+		return fmt.Sprintf("%d files", len(changed))
+	}
+	return strings.Join(changed, ", ")
+}
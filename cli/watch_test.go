@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/spf13/afero"
+)
+
+func TestWatchedFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for _, name := range []string{
+		"go.mod",
+		".reviveignore",
+		".gitignore",
+		"revive.toml",
+		"main.go",
+		"README.md",
+		"pkg/a.go",
+		"pkg/a_test.go",
+		"pkg/sub/b.go",
+		"pkg/sub/.reviveignore",
+		"pkg/testdata/c.go",
+		"pkg/.hidden/d.go",
+		"vendor/e.go",
+		"cmd/tool/main.go",
+	} {
+		if err := afero.WriteFile(fs, filepath.FromSlash(name), []byte("package x\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tt := range []struct {
+		patterns []string
+		want     []string
+	}{
+		{
+			patterns: []string{"./..."},
+			want:     []string{".reviveignore", "cmd/tool/main.go", "go.mod", "main.go", "pkg/a.go", "pkg/a_test.go", "pkg/sub/.reviveignore", "pkg/sub/b.go", "revive.toml"},
+		},
+		{
+			patterns: nil,
+			want:     []string{".reviveignore", "go.mod", "main.go", "revive.toml"},
+		},
+		{
+			patterns: []string{"pkg/...", "cmd/tool/main.go"},
+			want:     []string{".reviveignore", "cmd/tool/main.go", "go.mod", "pkg/a.go", "pkg/a_test.go", "pkg/sub/.reviveignore", "pkg/sub/b.go", "revive.toml"},
+		},
+		{
+			patterns: []string{"./pkg"},
+			want:     []string{".reviveignore", "go.mod", "pkg/a.go", "pkg/a_test.go", "revive.toml"},
+		},
+	} {
+		var got []string
+		for path := range watchedFiles(fs, tt.patterns, []string{".reviveignore"}, []string{"revive.toml"}) {
+			got = append(got, filepath.ToSlash(path))
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%v: got %v, want %v", tt.patterns, got, tt.want)
+		}
+	}
+}
+
+func TestResetsCache(t *testing.T) {
+	ignoreFiles := []string{".reviveignore"}
+	for path, want := range map[string]bool{
+		"go.mod":                     true,
+		"../go.work":                 true,
+		"pkg/.reviveignore":          true,
+		"pkg/.gitignore":             false,
+		"pkg/a.go":                   false,
+		".github/CODEOWNERS":         false,
+		filepath.Join("x", "go.mod"): true,
+	} {
+		if got := resetsCache(path, ignoreFiles); got != want {
+			t.Errorf("resetsCache(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestChangedFiles(t *testing.T) {
+	now := time.Now()
+	previous := map[string]fileState{
+		"a.go": {modTime: now, size: 10},
+		"b.go": {modTime: now, size: 10},
+		"c.go": {modTime: now, size: 10},
+		"d.go": {modTime: now, size: 10},
+	}
+	current := map[string]fileState{
+		"a.go": {modTime: now, size: 10},
+		"b.go": {modTime: now.Add(time.Second), size: 10},
+		"c.go": {modTime: now, size: 12},
+		"e.go": {modTime: now, size: 10},
+	}
+
+	if got, want := changedFiles(previous, current), []string{"b.go", "c.go", "d.go", "e.go"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got := changedFiles(current, current); len(got) != 0 {
+		t.Errorf("got %v, want no change", got)
+	}
+	if got, want := describeChanges([]string{"b.go", "c.go"}), "b.go, c.go"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := describeChanges([]string{"b.go", "c.go", "d.go", "e.go"}), "4 files"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
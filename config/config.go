@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"reflect"
 
 	"github.com/BurntSushi/toml"
 
@@ -116,14 +117,22 @@ var allRules = append([]lint.Rule{
 // allFormatters is a list of all available formatters to output the linting results.
 // Keep the list sorted and in sync with available formatters in README.md.
 var allFormatters = []lint.Formatter{
+	&formatter.AzurePipelines{},
 	&formatter.Checkstyle{},
+	&formatter.CodeClimate{},
 	&formatter.Default{},
 	&formatter.Friendly{},
+	&formatter.GitHubActions{},
+	&formatter.HTML{},
 	&formatter.JSON{},
+	&formatter.JUnit{},
+	&formatter.Markdown{},
 	&formatter.NDJSON{},
 	&formatter.Plain{},
 	&formatter.Sarif{},
 	&formatter.Stylish{},
+	&formatter.TeamCity{},
+	&formatter.Template{},
 	&formatter.Unix{},
 }
 
@@ -139,7 +148,9 @@ func getFormatters() map[string]lint.Formatter {
 func GetLintingRules(config *lint.Config, extraRules []lint.Rule) ([]lint.Rule, error) {
 	rulesMap := map[string]lint.Rule{}
 	for _, r := range allRules {
-		rulesMap[r.Name()] = r
+		// every configuration gets its own instances of the rules, for the configurations
+		// of a process not to share the arguments of the rules
+		rulesMap[r.Name()] = newRule(r)
 	}
 	for _, r := range extraRules {
 		if _, ok := rulesMap[r.Name()]; ok {
@@ -148,9 +159,15 @@ func GetLintingRules(config *lint.Config, extraRules []lint.Rule) ([]lint.Rule,
 		rulesMap[r.Name()] = r
 	}
 
+	for i, excludeRule := range config.ExcludeRules {
+		if excludeRule.Rule != "" && excludeRule.Rule != lint.DirectiveSpecifyDisableReason && rulesMap[lint.ActualRuleName(excludeRule.Rule)] == nil {
+			return nil, fmt.Errorf("cannot find rule %s of exclude-rule #%d", excludeRule.Rule, i+1)
+		}
+	}
+
 	var lintingRules []lint.Rule
 	for name, ruleConfig := range config.Rules {
-		actualName := actualRuleName(name)
+		actualName := lint.ActualRuleName(name)
 		r, ok := rulesMap[actualName]
 		if !ok {
 			return nil, fmt.Errorf("cannot find rule: %s", name)
@@ -172,13 +189,9 @@ func GetLintingRules(config *lint.Config, extraRules []lint.Rule) ([]lint.Rule,
 	return lintingRules, nil
 }
 
-func actualRuleName(name string) string {
-	switch name {
-	case "imports-blacklist":
-		return "imports-blocklist"
-	default:
-		return name
-	}
+// newRule returns a new instance of a rule of revive, configured with its arguments by [GetLintingRules].
+func newRule(r lint.Rule) lint.Rule {
+	return reflect.New(reflect.TypeOf(r).Elem()).Interface().(lint.Rule)
 }
 
 func parseConfig(path string, config *lint.Config) error {
@@ -197,6 +210,24 @@ func parseConfig(path string, config *lint.Config) error {
 		}
 		config.Rules[k] = r
 	}
+	if err := config.Generated.Initialize(); err != nil {
+		return fmt.Errorf("error in config of generated : [%w]", err)
+	}
+	for i := range config.ExcludeRules {
+		if err := config.ExcludeRules[i].Initialize(); err != nil {
+			return fmt.Errorf("error in config of exclude-rule #%d : [%w]", i+1, err)
+		}
+	}
+	for i, output := range config.Outputs {
+		if _, err := GetFormatter(output.Formatter); err != nil {
+			return fmt.Errorf("error in config of output #%d : [%w]", i+1, err)
+		}
+	}
+	for name := range config.Formatters {
+		if _, err := GetFormatter(name); err != nil {
+			return fmt.Errorf("error in config of formatter [%s] : [%w]", name, err)
+		}
+	}
 
 	return nil
 }
@@ -2,6 +2,7 @@ package config
 
 import (
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
@@ -44,6 +45,26 @@ func TestGetConfig(t *testing.T) {
 			confPath:       "testdata/issue-585-defaultConfidence.toml",
 			wantConfidence: defaultConfidence,
 		},
+		"unknown output formatter": {
+			confPath:  "testdata/unknownOutputFormatter.toml",
+			wantError: "error in config of output #1 : [unknown formatter unknown]",
+		},
+		"unknown formatter in formatter config": {
+			confPath:  "testdata/unknownFormatterConfig.toml",
+			wantError: "error in config of formatter [unknown] : [unknown formatter unknown]",
+		},
+		"exclude-rule without criteria": {
+			confPath:  "testdata/excludeRuleWithoutCriteria.toml",
+			wantError: "error in config of exclude-rule #1 : [the entry matches all the failures",
+		},
+		"invalid generated mode of a rule": {
+			confPath:  "testdata/generatedInvalidMode.toml",
+			wantError: `error in config of rule [unhandled-error] : [invalid generated mode "always"`,
+		},
+		"invalid generated header": {
+			confPath:  "testdata/generatedInvalidHeader.toml",
+			wantError: "error in config of generated : [invalid header regular expression",
+		},
 	}
 
 	for name, tc := range tt {
@@ -82,6 +103,20 @@ func TestGetConfig(t *testing.T) {
 			t.Fatal("r2 should not exclude some/any-other.go")
 		}
 	})
+
+	t.Run("outputs", func(t *testing.T) {
+		cfg, err := GetConfig("testdata/outputs.toml")
+		if err != nil {
+			t.Fatal("should be valid config")
+		}
+		want := []lint.OutputConfig{
+			{Formatter: "sarif", Path: "revive.sarif"},
+			{Formatter: "friendly"},
+		}
+		if !reflect.DeepEqual(cfg.Outputs, want) {
+			t.Fatalf("Expected outputs\n\t%+v\ngot:\n\t%+v", want, cfg.Outputs)
+		}
+	})
 }
 
 func TestGetLintingRules(t *testing.T) {
@@ -110,6 +145,10 @@ func TestGetLintingRules(t *testing.T) {
 			confPath: "testdata/varNamingConfigureError.toml",
 			wantErr:  `cannot configure rule: "var-naming": invalid argument to the var-naming rule. Expecting a allowlist of type slice with initialisms, got string`,
 		},
+		"exclude-rule of an unknown rule": {
+			confPath: "testdata/excludeRuleUnknownRule.toml",
+			wantErr:  "cannot find rule unknown-rule of exclude-rule #1",
+		},
 	}
 
 	for name, tc := range tt {
@@ -136,6 +175,29 @@ func TestGetLintingRules(t *testing.T) {
 	}
 }
 
+func TestGetLintingRulesInstances(t *testing.T) {
+	cfg, err := GetConfig("testdata/enable2.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	first, err := GetLintingRules(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := GetLintingRules(cfg, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the configurations do not share the configured instances of the rules
+	for _, r := range first {
+		if slices.Contains(second, r) {
+			t.Errorf("rule %s is shared by the configurations", r.Name())
+		}
+	}
+}
+
 func TestGetGlobalSeverity(t *testing.T) {
 	tt := map[string]struct {
 		confPath               string
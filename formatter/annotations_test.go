@@ -0,0 +1,46 @@
+package formatter
+
+import "testing"
+
+func TestAnnotationEscaping(t *testing.T) {
+	const s = "100% sure: a, b; [c]\r\nd'|e\u2028"
+	tests := []struct {
+		name   string
+		escape func(string) string
+		want   string
+	}{
+		{
+			name:   "GitHub Actions data",
+			escape: githubActionsData,
+			want:   "100%25 sure: a, b; [c]%0D%0Ad'|e\u2028",
+		},
+		{
+			name:   "GitHub Actions property",
+			escape: githubActionsProperty,
+			want:   "100%25 sure%3A a%2C b; [c]%0D%0Ad'|e\u2028",
+		},
+		{
+			name:   "Azure Pipelines message",
+			escape: azurePipelinesMessage,
+			want:   "100%AZP25 sure: a, b; [c]%0D%0Ad'|e\u2028",
+		},
+		{
+			name:   "Azure Pipelines property",
+			escape: azurePipelinesProperty,
+			want:   "100%AZP25 sure: a, b%3B [c%5D%0D%0Ad'|e\u2028",
+		},
+		{
+			name:   "TeamCity value",
+			escape: teamCityValue,
+			want:   "100% sure: a, b; |[c|]|r|nd|'||e|l",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.escape(s); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
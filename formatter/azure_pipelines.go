@@ -0,0 +1,54 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// AzurePipelines is an implementation of the Formatter interface
+// which formats the failures as Azure Pipelines logging commands, reported as issues of the build
+//
+//	##vso[task.logissue type=warning;sourcepath=main.go;linenumber=24;columnnumber=9;code=errorf;]should replace errors.New(fmt.Sprintf(...)) with fmt.Errorf(...)
+type AzurePipelines struct {
+	Metadata lint.FormatterMetadata
+}
+
+// Name returns the name of the formatter.
+func (*AzurePipelines) Name() string {
+	return "azure-pipelines"
+}
+
+// Format formats the failures gotten from the lint.
+func (f *AzurePipelines) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (*AzurePipelines) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	return &lineWriter{w: w, line: func(failure lint.Failure) string {
+		var properties strings.Builder
+		fmt.Fprintf(&properties, "type=%s;sourcepath=%s;", severity(config, failure), azurePipelinesProperty(relativePath(failure.Filename())))
+		if start := failure.Position.Start; start.Line > 0 {
+			fmt.Fprintf(&properties, "linenumber=%d;", start.Line)
+			if start.Column > 0 {
+				fmt.Fprintf(&properties, "columnnumber=%d;", start.Column)
+			}
+		}
+		fmt.Fprintf(&properties, "code=%s;", azurePipelinesProperty(failure.RuleName))
+
+		return fmt.Sprintf("##vso[task.logissue %s]%s", properties.String(), azurePipelinesMessage(failure.Failure))
+	}}, nil
+}
+
+// azurePipelinesMessage escapes the message of a logging command.
+func azurePipelinesMessage(s string) string {
+	return strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// azurePipelinesProperty escapes the value of a property of a logging command.
+func azurePipelinesProperty(s string) string {
+	return strings.NewReplacer("%", "%AZP25", "\r", "%0D", "\n", "%0A", ";", "%3B", "]", "%5D").Replace(s)
+}
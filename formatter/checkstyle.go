@@ -3,6 +3,7 @@ package formatter
 import (
 	"bytes"
 	"encoding/xml"
+	"io"
 	plain "text/template"
 
 	"github.com/mgechev/revive/lint"
@@ -29,40 +30,47 @@ type issue struct {
 }
 
 // Format formats the failures gotten from the lint.
-func (*Checkstyle) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
-	issues := map[string][]issue{}
-	for failure := range failures {
-		buf := new(bytes.Buffer)
-		xml.Escape(buf, []byte(failure.Failure))
-		what := buf.String()
-		iss := issue{
-			Line:       failure.Position.Start.Line,
-			Col:        failure.Position.Start.Column,
-			What:       what,
-			Confidence: failure.Confidence,
-			Severity:   severity(config, failure),
-			RuleName:   failure.RuleName,
-		}
-		fn := failure.Filename()
-		if issues[fn] == nil {
-			issues[fn] = []issue{}
-		}
-		issues[fn] = append(issues[fn], iss)
-	}
+func (f *Checkstyle) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
 
+// Begin starts a report written to w.
+func (*Checkstyle) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
 	t, err := plain.New("revive").Parse(checkstyleTemplate)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	buf := new(bytes.Buffer)
+	return &checkstyleWriter{w: w, config: config, template: t, issues: map[string][]issue{}}, nil
+}
 
-	err = t.Execute(buf, issues)
-	if err != nil {
-		return "", err
+// checkstyleWriter writes the failures grouped by file at the end.
+type checkstyleWriter struct {
+	w        io.Writer
+	config   lint.Config
+	template *plain.Template
+	issues   map[string][]issue
+}
+
+func (fw *checkstyleWriter) Failure(failure lint.Failure) error {
+	buf := new(bytes.Buffer)
+	xml.Escape(buf, []byte(failure.Failure))
+	what := buf.String()
+	iss := issue{
+		Line:       failure.Position.Start.Line,
+		Col:        failure.Position.Start.Column,
+		What:       what,
+		Confidence: failure.Confidence,
+		Severity:   severity(fw.config, failure),
+		RuleName:   failure.RuleName,
 	}
+	fn := failure.Filename()
+	fw.issues[fn] = append(fw.issues[fn], iss)
+	return nil
+}
 
-	return buf.String(), nil
+func (fw *checkstyleWriter) End() error {
+	return fw.template.Execute(fw.w, fw.issues)
 }
 
 const checkstyleTemplate = `<?xml version='1.0' encoding='UTF-8'?>
@@ -0,0 +1,173 @@
+package formatter
+
+import (
+	"cmp"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// CodeClimate is an implementation of the Formatter interface
+// which formats the failures as a Code Climate report, like the code quality reports of GitLab.
+//
+// The Code Climate severities of the failures can be set in the [formatter.codeclimate] section
+// of the configuration, by revive severity and by rule:
+//
+//	[formatter.codeclimate]
+//	severity = { error = "critical", warning = "minor" }
+//	rules = { cyclomatic = "major" }
+type CodeClimate struct {
+	Metadata lint.FormatterMetadata
+}
+
+// Name returns the name of the formatter.
+func (*CodeClimate) Name() string {
+	return "codeclimate"
+}
+
+// Code Climate severities.
+const (
+	codeClimateInfo     = "info"
+	codeClimateMinor    = "minor"
+	codeClimateMajor    = "major"
+	codeClimateCritical = "critical"
+	codeClimateBlocker  = "blocker"
+)
+
+// codeClimateIssue defines the JSON object of an issue of a Code Climate report.
+type codeClimateIssue struct {
+	Description string              `json:"description"`
+	CheckName   string              `json:"check_name"`
+	Fingerprint string              `json:"fingerprint"`
+	Severity    string              `json:"severity"`
+	Location    codeClimateLocation `json:"location"`
+	// position sorts the issues sharing a fingerprint
+	position lint.FailurePosition
+}
+
+type codeClimateLocation struct {
+	Path  string           `json:"path"`
+	Lines codeClimateLines `json:"lines"`
+}
+
+type codeClimateLines struct {
+	Begin int `json:"begin"`
+	End   int `json:"end"`
+}
+
+// Format formats the failures gotten from the lint.
+func (f *CodeClimate) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (f *CodeClimate) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	fw := &codeClimateWriter{
+		w:        w,
+		config:   config,
+		severity: map[lint.Severity]string{lint.SeverityError: codeClimateMajor, lint.SeverityWarning: codeClimateMinor},
+		rules:    map[string]string{},
+	}
+	options := config.Formatters[f.Name()]
+	if err := codeClimateSeverities(options["severity"], func(key, value string) { fw.severity[lint.Severity(key)] = value }); err != nil {
+		return nil, fmt.Errorf("invalid severity option: %w", err)
+	}
+	if err := codeClimateSeverities(options["rules"], func(key, value string) { fw.rules[key] = value }); err != nil {
+		return nil, fmt.Errorf("invalid rules option: %w", err)
+	}
+
+	return fw, nil
+}
+
+// codeClimateSeverities calls set for each entry of a table of Code Climate severities.
+func codeClimateSeverities(option any, set func(key, value string)) error {
+	if option == nil {
+		return nil
+	}
+
+	table, ok := option.(map[string]any)
+	if !ok {
+		return fmt.Errorf("expected a table, got %T", option)
+	}
+	for key, value := range table {
+		severity, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected a string for %s, got %T", key, value)
+		}
+		switch severity {
+		case codeClimateInfo, codeClimateMinor, codeClimateMajor, codeClimateCritical, codeClimateBlocker:
+		default:
+			return fmt.Errorf("unknown Code Climate severity %q for %s", severity, key)
+		}
+		set(key, severity)
+	}
+
+	return nil
+}
+
+// codeClimateWriter writes the issues as a JSON array at the end, once the fingerprints of identical failures are made unique.
+type codeClimateWriter struct {
+	w        io.Writer
+	config   lint.Config
+	severity map[lint.Severity]string
+	rules    map[string]string
+	issues   []codeClimateIssue
+}
+
+func (fw *codeClimateWriter) Failure(failure lint.Failure) error {
+	level, ok := fw.rules[failure.RuleName]
+	if !ok {
+		level = fw.severity[severity(fw.config, failure)]
+	}
+
+	begin := max(failure.Position.Start.Line, 1)
+	fw.issues = append(fw.issues, codeClimateIssue{
+		Description: failure.Failure,
+		CheckName:   failure.RuleName,
+		Fingerprint: fingerprint(failure),
+		Severity:    level,
+		Location: codeClimateLocation{
+			Path:  relativePath(failure.Filename()),
+			Lines: codeClimateLines{Begin: begin, End: max(failure.Position.End.Line, begin)},
+		},
+		position: failure.Position,
+	})
+	return nil
+}
+
+func (fw *codeClimateWriter) End() error {
+	// Code Climate requires unique fingerprints: identical failures of a rule in the same file
+	// are told apart by their order in the file.
+	slices.SortStableFunc(fw.issues, func(a, b codeClimateIssue) int {
+		return cmp.Or(
+			cmp.Compare(a.Location.Path, b.Location.Path),
+			cmp.Compare(a.position.Start.Line, b.position.Start.Line),
+			cmp.Compare(a.position.Start.Column, b.position.Start.Column),
+		)
+	})
+	occurrences := map[string]int{}
+	for i, issue := range fw.issues {
+		if occurrence := occurrences[issue.Fingerprint]; occurrence > 0 {
+			sum := sha256.Sum256([]byte(issue.Fingerprint + "\x00" + strconv.Itoa(occurrence)))
+			fw.issues[i].Fingerprint = hex.EncodeToString(sum[:])
+		}
+		occurrences[issue.Fingerprint]++
+	}
+
+	if fw.issues == nil {
+		fw.issues = []codeClimateIssue{}
+	}
+	result, err := json.Marshal(fw.issues)
+	if err != nil {
+		return err
+	}
+
+	_, err = fw.w.Write(result)
+	return err
+}
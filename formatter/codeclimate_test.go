@@ -0,0 +1,79 @@
+package formatter
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestCodeClimate(t *testing.T) {
+	config := lint.Config{
+		Rules: lint.RulesConfig{
+			"rule":       {Severity: lint.SeverityError},
+			"other-rule": {},
+		},
+		Formatters: lint.FormattersConfig{
+			"codeclimate": {
+				"severity": map[string]any{"error": "blocker"},
+				"rules":    map[string]any{"other-rule": "info"},
+			},
+		},
+	}
+	otherRule := testFailure("other failure", "")
+	otherRule.RuleName = "other-rule"
+	duplicate := testFailure("test failure", "")
+	duplicate.Position.Start.Line = 1
+	failures := make(chan lint.Failure, 3)
+	failures <- testFailure("test failure", "")
+	failures <- otherRule
+	failures <- duplicate
+	close(failures)
+
+	output, err := (&CodeClimate{}).Format(failures, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var issues []codeClimateIssue
+	if err := json.Unmarshal([]byte(output), &issues); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if len(issues) != 3 {
+		t.Fatalf("got %d issues, want 3: %s", len(issues), output)
+	}
+
+	// the issues are sorted by position, the first of identical failures keeps the fingerprint of the failure
+	if got, want := issues[0].Fingerprint, fingerprint(duplicate); got != want {
+		t.Errorf("got fingerprint %s for the first failure, want %s", got, want)
+	}
+	if issues[0].Fingerprint == issues[1].Fingerprint {
+		t.Errorf("identical failures have the same fingerprint %s", issues[0].Fingerprint)
+	}
+	wantSeverities := []string{codeClimateBlocker, codeClimateBlocker, codeClimateInfo}
+	for i, issue := range issues {
+		if issue.Severity != wantSeverities[i] {
+			t.Errorf("got severity %s for issue %d, want %s", issue.Severity, i, wantSeverities[i])
+		}
+	}
+	if got := issues[2].Location; got.Path != "pkg/test.go" || got.Lines.Begin != 2 || got.Lines.End != 2 {
+		t.Errorf("got location %+v", got)
+	}
+}
+
+func TestCodeClimate_invalidConfig(t *testing.T) {
+	tests := map[string]lint.FormatterConfig{
+		"not a table":      {"severity": "major"},
+		"not a string":     {"rules": map[string]any{"rule": 1}},
+		"unknown severity": {"severity": map[string]any{"warning": "low"}},
+	}
+
+	for name, options := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := lint.Config{Formatters: lint.FormattersConfig{"codeclimate": options}}
+			if _, err := (&CodeClimate{}).Begin(nil, config); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package formatter
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// codeFrameOption is the option of the [formatter.friendly] and [formatter.stylish] sections
+// of the configuration that shows the source code under each failure.
+const codeFrameOption = "code-frame"
+
+const (
+	// codeFrameContext is the number of lines shown before and after the lines of a failure.
+	codeFrameContext = 1
+	// codeFrameMaxLines is the maximum number of lines of a failure shown in its code frame.
+	codeFrameMaxLines = 5
+)
+
+// codeFrameEnabled tells if the code frames are enabled in the options of a formatter.
+func codeFrameEnabled(options lint.FormatterConfig) (bool, error) {
+	value, ok := options[codeFrameOption]
+	if !ok {
+		return false, nil
+	}
+	enabled, ok := value.(bool)
+	if !ok {
+		return false, fmt.Errorf("invalid %s option: expected a boolean, got %T", codeFrameOption, value)
+	}
+	return enabled, nil
+}
+
+// codeFrame returns the lines of the failure with their numbers, each line prefixed with indent.
+// A failure on a single line is underlined with carets, the lines of a failure on several lines
+// are marked in the gutter.
+//
+// It returns an empty string if the source code of the failure is unknown.
+func codeFrame(failure lint.Failure, severity lint.Severity, indent string) string {
+	lines := sourceExcerpt(failure, codeFrameContext, codeFrameMaxLines)
+	if len(lines) == 0 {
+		return ""
+	}
+
+	marker := color.New(color.FgYellow, color.Bold)
+	if severity == lint.SeverityError {
+		marker = color.New(color.FgRed, color.Bold)
+	}
+	gutter := color.New(color.Faint)
+
+	first, last := failure.Position.Start.Line, max(failure.Position.End.Line, failure.Position.Start.Line)
+	multiline := last > first
+
+	width := len(fmt.Sprint(lines[len(lines)-1].Number))
+	var sb strings.Builder
+	for _, line := range lines {
+		inFailure := line.Number >= first && line.Number <= last
+		mark := " "
+		if inFailure && multiline {
+			mark = marker.Sprint(">")
+		}
+		fmt.Fprintf(&sb, "%s%s %s %s%s%s\n", indent, mark, gutter.Sprintf("%*d |", width, line.Number), line.Before, line.Failure, line.After)
+
+		if inFailure && !multiline {
+			carets := max(utf8.RuneCountInString(line.Failure), 1)
+			fmt.Fprintf(&sb, "%s  %s %s%s\n", indent, gutter.Sprintf("%*s |", width, ""), codeFrameIndent(line.Before), marker.Sprint(strings.Repeat("^", carets)))
+		}
+	}
+
+	return sb.String()
+}
+
+// codeFrameIndent returns the blank text as wide as the given text on a terminal, keeping its tabs.
+func codeFrameIndent(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\t' {
+			return r
+		}
+		return ' '
+	}, text)
+}
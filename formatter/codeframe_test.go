@@ -0,0 +1,108 @@
+package formatter
+
+import (
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/fatih/color"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestCodeFrame(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	content := []byte("package pkg\n\nfunc f() {\n\tx := g()\n}\n")
+	tests := []struct {
+		name       string
+		start, end token.Position
+		want       string
+	}{
+		{
+			name:  "single line",
+			start: token.Position{Line: 4, Column: 7},
+			end:   token.Position{Line: 4, Column: 10},
+			want: `
+  3 | func f() {
+  4 | 	x := g()
+    | 	     ^^^
+  5 | }
+`,
+		},
+		{
+			name:  "several lines",
+			start: token.Position{Line: 3, Column: 1},
+			end:   token.Position{Line: 5, Column: 2},
+			want: `
+  2 | 
+> 3 | func f() {
+> 4 | 	x := g()
+> 5 | }
+  6 | 
+`,
+		},
+		{
+			name:  "unknown end",
+			start: token.Position{Line: 5, Column: 1},
+			want: `
+  4 | 	x := g()
+  5 | }
+    | ^
+  6 | 
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failure := lint.Failure{Position: lint.FailurePosition{Start: tt.start, End: tt.end}, FileContent: content}
+			got := codeFrame(failure, lint.SeverityWarning, "")
+			if want := strings.TrimPrefix(tt.want, "\n"); got != want {
+				t.Errorf("got\n%s\nwant\n%s", got, want)
+			}
+		})
+	}
+}
+
+func TestCodeFrame_unknownSource(t *testing.T) {
+	if got := codeFrame(testFailure("test failure", ""), lint.SeverityWarning, ""); got != "" {
+		t.Errorf("got %q, want no code frame", got)
+	}
+}
+
+func TestFriendly_codeFrame(t *testing.T) {
+	failure := testFailure("test failure", "")
+	failure.FileContent = []byte("package pkg\nvar a, bc = 1, 2\n")
+	failures := make(chan lint.Failure, 1)
+	failures <- failure
+	close(failures)
+
+	config := lint.Config{Formatters: lint.FormattersConfig{"friendly": {codeFrameOption: true}}}
+	got, err := (&Friendly{}).Format(failures, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "  pkg/test.go:2:5\n    1 | package pkg\n    2 | var a, bc = 1, 2\n      |     ^^^^^\n    3 | \n\n"; !strings.Contains(got, want) {
+		t.Errorf("got %q, want it to contain %q", got, want)
+	}
+}
+
+func TestStylish_codeFrame(t *testing.T) {
+	failure := testFailure("test failure", "")
+	failure.FileContent = []byte("package pkg\nvar a, bc = 1, 2\n")
+	failures := make(chan lint.Failure, 1)
+	failures <- failure
+	close(failures)
+
+	config := lint.Config{Formatters: lint.FormattersConfig{"stylish": {codeFrameOption: true}}}
+	got, err := (&Stylish{}).Format(failures, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "  \n      1 | package pkg\n      2 | var a, bc = 1, 2\n        |     ^^^^^\n      3 | \n\n"; !strings.Contains(got, want) {
+		t.Errorf("got %q, want it to contain %q", got, want)
+	}
+}
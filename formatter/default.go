@@ -1,8 +1,8 @@
 package formatter
 
 import (
-	"bytes"
 	"fmt"
+	"io"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -19,12 +19,15 @@ func (*Default) Name() string {
 }
 
 // Format formats the failures gotten from the lint.
-func (*Default) Format(failures <-chan lint.Failure, _ lint.Config) (string, error) {
-	var buf bytes.Buffer
-	for failure := range failures {
-		fmt.Fprintf(&buf, "%v: %s\n", failure.Position.Start, failure.Failure)
-	}
-	return buf.String(), nil
+func (f *Default) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (*Default) Begin(w io.Writer, _ lint.Config) (lint.FailureWriter, error) {
+	return &lineWriter{w: w, line: func(failure lint.Failure) string {
+		return fmt.Sprintf("%v: %s", failure.Position.Start, failure.Failure)
+	}}, nil
 }
 
 func ruleDescriptionURL(ruleName string) string {
@@ -32,6 +32,10 @@ func TestFormatter(t *testing.T) {
 		formatter lint.Formatter
 		want      string
 	}{
+		{
+			formatter: &formatter.AzurePipelines{},
+			want:      `##vso[task.logissue type=warning;sourcepath=test.go;linenumber=2;columnnumber=5;code=rule;]test failure`,
+		},
 		{
 			formatter: &formatter.Checkstyle{},
 			want: `
@@ -43,6 +47,11 @@ func TestFormatter(t *testing.T) {
 </checkstyle>
 `,
 		},
+		{
+			formatter: &formatter.CodeClimate{},
+			//revive:disable-next-line // line-length-limit
+			want: `[{"description":"test failure","check_name":"rule","fingerprint":"9fdfe10f1582c46a4498134e582e88672cd55161c2629d9e3941e7497d1bc2af","severity":"minor","location":{"path":"test.go","lines":{"begin":2,"end":2}}}]`, //nolint:revive // line-length-limit
+		},
 		{
 			formatter: &formatter.Default{},
 			want:      `test.go:2:5: test failure`,
@@ -60,58 +69,49 @@ Warnings:
 `,
 		},
 		{
-			formatter: &formatter.JSON{},
-			//revive:disable-next-line // line-length-limit
-			want: `[{"Severity":"warning","Failure":"test failure","RuleName":"rule","Category":"cat","Position":{"Start":{"Filename":"test.go","Offset":0,"Line":2,"Column":5},"End":{"Filename":"test.go","Offset":0,"Line":2,"Column":10}},"Confidence":0,"ReplacementLine":""}]`, //nolint:revive // line-length-limit
+			formatter: &formatter.GitHubActions{},
+			want:      `::warning file=test.go,line=2,col=5,endLine=2,endColumn=10,title=rule::test failure`,
 		},
 		{
-			formatter: &formatter.NDJSON{},
-			//revive:disable-next-line // line-length-limit
-			want: `{"Severity":"warning","Failure":"test failure","RuleName":"rule","Category":"cat","Position":{"Start":{"Filename":"test.go","Offset":0,"Line":2,"Column":5},"End":{"Filename":"test.go","Offset":0,"Line":2,"Column":10}},"Confidence":0,"ReplacementLine":""}`, //nolint:revive // line-length-limit
-		},
-		{
-			formatter: &formatter.Plain{},
-			want:      `test.go:2:5: test failure https://revive.run/r#rule`,
+			formatter: &formatter.JUnit{},
+			want: `
+<?xml version="1.0" encoding="UTF-8"?>
+<testsuites name="revive" tests="1" failures="1" errors="0" skipped="0">
+  <testsuite name="." tests="1" failures="1" errors="0" skipped="0">
+    <testcase name="rule" classname="test" file="test.go">
+      <failure message="test failure" type="warning"><![CDATA[test.go:2:5: test failure]]></failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`,
 		},
 		{
-			formatter: &formatter.Sarif{},
+			formatter: &formatter.Markdown{},
 			want: `
-{
-  "runs": [
-    {
-      "results": [
-        {
-          "locations": [
-            {
-              "physicalLocation": {
-                "artifactLocation": {
-                  "uri": "test.go"
-                },
-                "region": {
-                  "startColumn": 5,
-                  "startLine": 2
-                }
-              }
-            }
-          ],
-          "message": {
-            "text": "test failure"
-          },
-          "ruleId": "rule"
-        }
-      ],
-      "tool": {
-        "driver": {
-          "informationUri": "https://revive.run",
-          "name": "revive"
-        }
-      }
-    }
-  ],
-  "version": "2.1.0"
-}
+### revive
+
+| Severity | Failures |
+| --- | ---: |
+| :x: error | 0 |
+| :warning: warning | 1 |
+| **total** | **1** |
+
+| Rule | Errors | Warnings |
+| --- | ---: | ---: |
+| [rule](https://revive.run/r#rule) | 0 | 1 |
+
+<details>
+<summary><code>test.go</code> (1)</summary>
+
+- :warning: ` + "`test.go:2`" + ` [rule](https://revive.run/r#rule): test failure
+
+</details>
 `,
 		},
+		{
+			formatter: &formatter.Plain{},
+			want:      `test.go:2:5: test failure https://revive.run/r#rule`,
+		},
 		{
 			formatter: &formatter.Stylish{},
 			want: `
@@ -120,6 +120,13 @@ test.go
 
 
  ✖ 1 problem (0 errors) (1 warning)
+`,
+		},
+		{
+			formatter: &formatter.TeamCity{},
+			want: `
+##teamcity[inspectionType id='rule' name='rule' description='rule' category='cat']
+##teamcity[inspection typeId='rule' message='test failure' file='test.go' line='2' SEVERITY='WARNING']
 `,
 		},
 		{
@@ -162,6 +169,25 @@ test.go
 			if got != want {
 				t.Errorf("got %q, want %q", got, want)
 			}
+
+			streamFormatter, ok := td.formatter.(lint.StreamFormatter)
+			if !ok {
+				t.Fatal("formatter does not implement lint.StreamFormatter")
+			}
+			var buf strings.Builder
+			fw, err := streamFormatter.Begin(&buf, lint.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := fw.Failure(lintFailure); err != nil {
+				t.Fatal(err)
+			}
+			if err := fw.End(); err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.TrimSpace(buf.String()); got != want {
+				t.Errorf("streamed: got %q, want %q", got, want)
+			}
 		})
 	}
 }
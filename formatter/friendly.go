@@ -25,36 +25,88 @@ func (*Friendly) Name() string {
 	return "friendly"
 }
 
+// WritesOwners tells that the formatter writes the owners of the failures.
+func (*Friendly) WritesOwners() bool {
+	return true
+}
+
 // Format formats the failures gotten from the lint.
 func (f *Friendly) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (f *Friendly) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	withCodeFrames, err := codeFrameEnabled(config.Formatters[f.Name()])
+	if err != nil {
+		return nil, err
+	}
+
+	return &friendlyWriter{
+		formatter:      f,
+		w:              w,
+		config:         config,
+		withCodeFrames: withCodeFrames,
+		errorMap:       map[string]int{},
+		warningMap:     map[string]int{},
+		owners:         map[string]*ownerCounts{},
+	}, nil
+}
+
+// friendlyWriter writes the failures as they arrive, and their statistics at the end.
+type friendlyWriter struct {
+	formatter      *Friendly
+	w              io.Writer
+	config         lint.Config
+	withCodeFrames bool
+	errorMap       map[string]int
+	warningMap     map[string]int
+	owners         map[string]*ownerCounts
+	totalErrors    int
+	totalWarnings  int
+}
+
+func (fw *friendlyWriter) Failure(failure lint.Failure) error {
 	var buf strings.Builder
-	errorMap := map[string]int{}
-	warningMap := map[string]int{}
-	totalErrors := 0
-	totalWarnings := 0
-	for failure := range failures {
-		sev := severity(config, failure)
-		f.printFriendlyFailure(&buf, failure, sev)
-		switch sev {
-		case lint.SeverityWarning:
-			warningMap[failure.RuleName]++
-			totalWarnings++
-		case lint.SeverityError:
-			errorMap[failure.RuleName]++
-			totalErrors++
-		}
+	sev := severity(fw.config, failure)
+	var frame string
+	if fw.withCodeFrames {
+		frame = codeFrame(failure, sev, "  ")
+	}
+	fw.formatter.printFriendlyFailure(&buf, failure, sev, frame)
+	switch sev {
+	case lint.SeverityWarning:
+		fw.warningMap[failure.RuleName]++
+		fw.totalWarnings++
+	case lint.SeverityError:
+		fw.errorMap[failure.RuleName]++
+		fw.totalErrors++
 	}
+	addOwnerCounts(fw.owners, failure, sev)
+
+	_, err := io.WriteString(fw.w, buf.String())
+	return err
+}
+
+func (fw *friendlyWriter) End() error {
+	var buf strings.Builder
+	fw.formatter.printSummary(&buf, fw.totalErrors, fw.totalWarnings)
+	fw.formatter.printStatistics(&buf, color.RedString("Errors:"), fw.errorMap)
+	fw.formatter.printStatistics(&buf, color.YellowString("Warnings:"), fw.warningMap)
+	fw.formatter.printStatistics(&buf, color.CyanString("Owners:"), ownerTotals(fw.owners))
 
-	f.printSummary(&buf, totalErrors, totalWarnings)
-	f.printStatistics(&buf, color.RedString("Errors:"), errorMap)
-	f.printStatistics(&buf, color.YellowString("Warnings:"), warningMap)
-	return buf.String(), nil
+	_, err := io.WriteString(fw.w, buf.String())
+	return err
 }
 
-func (f *Friendly) printFriendlyFailure(sb *strings.Builder, failure lint.Failure, severity lint.Severity) {
+// printFriendlyFailure prints the failure, followed by its code frame if any.
+func (f *Friendly) printFriendlyFailure(sb *strings.Builder, failure lint.Failure, severity lint.Severity, codeFrame string) {
 	f.printHeaderRow(sb, failure, severity)
 	f.printFilePosition(sb, failure)
-	sb.WriteString("\n\n")
+	sb.WriteString("\n")
+	f.printRelated(sb, failure)
+	sb.WriteString(codeFrame)
+	sb.WriteString("\n")
 }
 
 var errorEmoji = color.RedString("✘")
@@ -72,6 +124,14 @@ func (*Friendly) printFilePosition(sb *strings.Builder, failure lint.Failure) {
 	fmt.Fprintf(sb, "  %s:%d:%d", failure.Filename(), failure.Position.Start.Line, failure.Position.Start.Column)
 }
 
+// printRelated prints the locations related to the failure, one per line.
+func (*Friendly) printRelated(sb *strings.Builder, failure lint.Failure) {
+	for _, related := range failure.Related {
+		start := related.Position.Start
+		fmt.Fprintf(sb, "    ↳ %s:%d:%d %s\n", start.Filename, start.Line, start.Column, color.CyanString(related.Message))
+	}
+}
+
 type statEntry struct {
 	name     string
 	failures int
@@ -109,19 +169,24 @@ func (*Friendly) printStatistics(w io.Writer, header string, stats map[string]in
 	if len(stats) == 0 {
 		return
 	}
+	formatted := [][]string{}
+	for _, entry := range statistics(stats) {
+		formatted = append(formatted, []string{color.GreenString(fmt.Sprintf("%d", entry.failures)), entry.name})
+	}
+	fmt.Fprintln(w, header)
+	fmt.Fprintln(w, table(formatted))
+}
+
+// statistics returns the number of failures by name, sorted by decreasing number of failures.
+func statistics(stats map[string]int) []statEntry {
 	data := make([]statEntry, 0, len(stats))
 	for name, total := range stats {
 		data = append(data, statEntry{name, total})
 	}
 	slices.SortFunc(data, func(a, b statEntry) int {
-		return -cmp.Compare(a.failures, b.failures)
+		return cmp.Or(-cmp.Compare(a.failures, b.failures), strings.Compare(a.name, b.name))
 	})
-	formatted := [][]string{}
-	for _, entry := range data {
-		formatted = append(formatted, []string{color.GreenString(fmt.Sprintf("%d", entry.failures)), entry.name})
-	}
-	fmt.Fprintln(w, header)
-	fmt.Fprintln(w, table(formatted))
+	return data
 }
 
 func table(rows [][]string) string {
@@ -2,7 +2,13 @@ package formatter
 
 import (
 	"bytes"
+	"go/token"
+	"strings"
 	"testing"
+
+	"github.com/fatih/color"
+
+	"github.com/mgechev/revive/lint"
 )
 
 func TestFriendly_printStatistics(t *testing.T) {
@@ -87,3 +93,23 @@ func TestFriendly_table(t *testing.T) {
 		})
 	}
 }
+
+func TestFriendly_printRelated(t *testing.T) {
+	noColor := color.NoColor
+	color.NoColor = true
+	defer func() { color.NoColor = noColor }()
+
+	failure := testFailure("failure", "")
+	failure.Related = []lint.RelatedLocation{
+		{Message: "first", Position: lint.FailurePosition{Start: token.Position{Filename: "test.go", Line: 3, Column: 1}}},
+		{Message: "second", Position: lint.FailurePosition{Start: token.Position{Filename: "other.go", Line: 7, Column: 4}}},
+	}
+
+	var sb strings.Builder
+	(&Friendly{}).printRelated(&sb, failure)
+
+	want := "    ↳ test.go:3:1 first\n    ↳ other.go:7:4 second\n"
+	if got := sb.String(); got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
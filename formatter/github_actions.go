@@ -0,0 +1,60 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// GitHubActions is an implementation of the Formatter interface
+// which formats the failures as GitHub Actions workflow commands, shown as annotations of the code
+//
+//	::warning file=main.go,line=24,col=9,endLine=24,endColumn=30,title=errorf::should replace errors.New(fmt.Sprintf(...)) with fmt.Errorf(...)
+type GitHubActions struct {
+	Metadata lint.FormatterMetadata
+}
+
+// Name returns the name of the formatter.
+func (*GitHubActions) Name() string {
+	return "github-actions"
+}
+
+// Format formats the failures gotten from the lint.
+func (f *GitHubActions) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (*GitHubActions) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	return &lineWriter{w: w, line: func(failure lint.Failure) string {
+		properties := []string{"file=" + githubActionsProperty(relativePath(failure.Filename()))}
+		start, end := failure.Position.Start, failure.Position.End
+		if start.Line > 0 {
+			properties = append(properties, fmt.Sprintf("line=%d", start.Line))
+			if start.Column > 0 {
+				properties = append(properties, fmt.Sprintf("col=%d", start.Column))
+			}
+			if end.Line >= start.Line {
+				properties = append(properties, fmt.Sprintf("endLine=%d", end.Line))
+				if end.Column > 0 {
+					properties = append(properties, fmt.Sprintf("endColumn=%d", end.Column))
+				}
+			}
+		}
+		properties = append(properties, "title="+githubActionsProperty(failure.RuleName))
+
+		return fmt.Sprintf("::%s %s::%s", severity(config, failure), strings.Join(properties, ","), githubActionsData(failure.Failure))
+	}}, nil
+}
+
+// githubActionsData escapes the message of a workflow command.
+func githubActionsData(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(s)
+}
+
+// githubActionsProperty escapes the value of a property of a workflow command.
+func githubActionsProperty(s string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(s)
+}
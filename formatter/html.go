@@ -0,0 +1,419 @@
+package formatter
+
+import (
+	"cmp"
+	"html/template"
+	"io"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mgechev/revive/internal/ruledoc"
+	"github.com/mgechev/revive/lint"
+)
+
+// HTML is an implementation of the Formatter interface
+// which formats the failures as a self-contained HTML report.
+//
+// The report summarizes the failures by severity, category, rule and directory,
+// lists the failures with an excerpt of their source code, and documents the rules.
+// It works offline: the styles, the scripts and the data are embedded in the page.
+type HTML struct {
+	Metadata lint.FormatterMetadata
+}
+
+// Name returns the name of the formatter.
+func (*HTML) Name() string {
+	return "html"
+}
+
+const (
+	// htmlExcerptContext is the number of lines shown before and after the lines of a failure.
+	htmlExcerptContext = 2
+	// htmlExcerptMaxLines is the maximum number of lines of a failure shown in its excerpt.
+	htmlExcerptMaxLines = 8
+)
+
+// Format formats the failures gotten from the lint.
+func (f *HTML) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (*HTML) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	t, err := template.New("revive").Parse(htmlTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &htmlWriter{w: w, config: config, template: t}, nil
+}
+
+type htmlFailure struct {
+	Rule     string          `json:"rule"`
+	Category string          `json:"category"`
+	Severity lint.Severity   `json:"severity"`
+	Path     string          `json:"path"`
+	Line     int             `json:"line"`
+	Column   int             `json:"column"`
+	Message  string          `json:"message"`
+	Source   []htmlSourceRow `json:"source,omitempty"`
+}
+
+// htmlSourceRow is a line of an excerpt: its number, and its text before, in, and after the range of the failure.
+type htmlSourceRow struct {
+	Number int       `json:"n"`
+	Text   [3]string `json:"t"`
+}
+
+type htmlStat struct {
+	Name    string
+	Count   int
+	Percent int
+}
+
+type htmlSummary struct {
+	Title string
+	// Rules tells the stats are by rule, thus their names link to the documentation of the rules.
+	Rules bool
+	Stats []htmlStat
+}
+
+type htmlRule struct {
+	Name        string
+	Description string
+	URL         string
+}
+
+type htmlReport struct {
+	Revive    runInfo
+	Total     int
+	Errors    int
+	Warnings  int
+	Internal  []string
+	Summaries []htmlSummary
+	Rules     []htmlRule
+	Failures  []htmlFailure
+}
+
+// htmlWriter writes the report at the end, once the statistics are known.
+type htmlWriter struct {
+	w        io.Writer
+	config   lint.Config
+	template *template.Template
+	internal []string
+	failures []htmlFailure
+}
+
+func (fw *htmlWriter) Failure(failure lint.Failure) error {
+	if failure.IsInternal() {
+		fw.internal = append(fw.internal, failure.Failure)
+		return nil
+	}
+
+	var source []htmlSourceRow
+	for _, line := range sourceExcerpt(failure, htmlExcerptContext, htmlExcerptMaxLines) {
+		source = append(source, htmlSourceRow{Number: line.Number, Text: [3]string{line.Before, line.Failure, line.After}})
+	}
+	fw.failures = append(fw.failures, htmlFailure{
+		Rule:     failure.RuleName,
+		Category: string(failure.Category),
+		Severity: severity(fw.config, failure),
+		Path:     relativePath(failure.Filename()),
+		Line:     failure.Position.Start.Line,
+		Column:   failure.Position.Start.Column,
+		Message:  failure.Failure,
+		Source:   source,
+	})
+	return nil
+}
+
+func (fw *htmlWriter) End() error {
+	slices.SortStableFunc(fw.failures, func(a, b htmlFailure) int {
+		return cmp.Or(
+			strings.Compare(a.Path, b.Path),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+			strings.Compare(a.Rule, b.Rule),
+		)
+	})
+
+	report := htmlReport{
+		Revive:   newRunInfo(fw.config),
+		Total:    len(fw.failures),
+		Internal: fw.internal,
+		Failures: fw.failures,
+	}
+	if report.Failures == nil {
+		report.Failures = []htmlFailure{}
+	}
+
+	bySeverity, byCategory, byRule, byDirectory := map[string]int{}, map[string]int{}, map[string]int{}, map[string]int{}
+	for _, failure := range fw.failures {
+		if failure.Severity == lint.SeverityError {
+			report.Errors++
+		} else {
+			report.Warnings++
+		}
+		bySeverity[string(failure.Severity)]++
+		byCategory[failure.Category]++
+		byRule[failure.Rule]++
+		byDirectory[path.Dir(failure.Path)]++
+	}
+	report.Summaries = []htmlSummary{
+		{Title: "Severity", Stats: htmlStats(bySeverity)},
+		{Title: "Category", Stats: htmlStats(byCategory)},
+		{Title: "Rule", Rules: true, Stats: htmlStats(byRule)},
+		{Title: "Directory", Stats: htmlStats(byDirectory)},
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(byRule)) {
+		rule := htmlRule{Name: name, URL: ruleDescriptionURL(name)}
+		if description, ok := ruledoc.Of(name); ok {
+			rule.Description = description.Full
+		}
+		report.Rules = append(report.Rules, rule)
+	}
+
+	return fw.template.Execute(fw.w, report)
+}
+
+// htmlStats returns the stats sorted by decreasing count, with their percentage of the largest count.
+func htmlStats(counts map[string]int) []htmlStat {
+	entries := statistics(counts)
+	stats := make([]htmlStat, 0, len(entries))
+	for _, entry := range entries {
+		stats = append(stats, htmlStat{Name: entry.name, Count: entry.failures, Percent: entry.failures * 100 / entries[0].failures})
+	}
+	return stats
+}
+
+const htmlTemplate = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="generator" content="revive {{.Revive.ReviveVersion}}">
+<title>revive report</title>
+<style>
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; margin: 0 auto; max-width: 1200px; padding: 1em 2em; color: #1f2328; }
+h1 { font-size: 1.6em; }
+h2 { font-size: 1.25em; border-bottom: 1px solid #d0d7de; padding-bottom: .3em; margin-top: 2em; }
+a { color: #0969da; }
+code, pre, .mono { font-family: ui-monospace, SFMono-Regular, Menlo, Consolas, monospace; font-size: 12px; }
+.totals span { margin-right: 1.5em; }
+.error { color: #cf222e; }
+.warning { color: #9a6700; }
+.internal { background: #ffebe9; border: 1px solid #ff8182; padding: .5em 1em; }
+.summaries { display: grid; grid-template-columns: repeat(auto-fit, minmax(260px, 1fr)); gap: 1.5em; }
+.summary table { width: 100%; border-collapse: collapse; }
+.summary td { padding: 2px 4px; vertical-align: middle; }
+.summary .name { max-width: 180px; overflow: hidden; text-overflow: ellipsis; white-space: nowrap; }
+.summary .bar { width: 40%; }
+.summary .bar div { background: #54aeff; height: .8em; min-width: 1px; }
+.summary .count { text-align: right; font-variant-numeric: tabular-nums; }
+.summary .scroll { max-height: 300px; overflow-y: auto; }
+#controls { margin: 1em 0; display: flex; gap: 1em; align-items: center; flex-wrap: wrap; }
+#controls input { flex: 1; min-width: 200px; padding: 4px 8px; }
+#failures { width: 100%; border-collapse: collapse; }
+#failures th { text-align: left; cursor: pointer; user-select: none; border-bottom: 2px solid #d0d7de; padding: 4px; white-space: nowrap; }
+#failures th[data-dir="1"]::after { content: " \25B2"; }
+#failures th[data-dir="-1"]::after { content: " \25BC"; }
+#failures td { border-bottom: 1px solid #eaeef2; padding: 4px; vertical-align: top; }
+#failures tr.failure { cursor: pointer; }
+#failures tr.failure:hover { background: #f6f8fa; }
+pre.source { margin: 0; padding: .5em 0; background: #f6f8fa; overflow-x: auto; }
+pre.source .line { display: block; padding: 0 1em; }
+pre.source .number { display: inline-block; width: 4em; color: #6e7781; user-select: none; }
+pre.source mark { background: #ffd8b5; text-decoration: underline wavy #cf222e; }
+#pages button { margin: 0 .25em; }
+dt { font-weight: bold; margin-top: 1em; }
+dd { margin-left: 1.5em; }
+</style>
+</head>
+<body>
+<h1>revive report</h1>
+<p class="totals">
+<span><strong>{{.Total}}</strong> problems</span>
+<span class="error"><strong>{{.Errors}}</strong> errors</span>
+<span class="warning"><strong>{{.Warnings}}</strong> warnings</span>
+<span class="mono">revive {{.Revive.ReviveVersion}}, {{.Revive.GoVersion}}</span>
+</p>
+{{- if .Internal}}
+<div class="internal">
+<p><strong>revive could not lint the code:</strong></p>
+<ul>
+{{- range .Internal}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+</div>
+{{- end}}
+
+<h2>Summary</h2>
+<div class="summaries">
+{{- range .Summaries}}
+{{- $rules := .Rules}}
+<div class="summary">
+<h3>{{.Title}}</h3>
+<div class="scroll">
+<table>
+{{- range .Stats}}
+<tr>
+<td class="name" title="{{.Name}}">{{if $rules}}<a href="#rule-{{.Name}}">{{.Name}}</a>{{else}}{{.Name}}{{end}}</td>
+<td class="bar"><div style="width: {{.Percent}}%"></div></td>
+<td class="count">{{.Count}}</td>
+</tr>
+{{- end}}
+</table>
+</div>
+</div>
+{{- end}}
+</div>
+
+<h2>Failures</h2>
+<div id="controls">
+<input id="filter" type="search" placeholder="Filter by file, rule, category, severity or message">
+<span id="count"></span>
+</div>
+<table id="failures">
+<thead>
+<tr>
+<th data-key="path">File</th>
+<th data-key="line">Line</th>
+<th data-key="severity">Severity</th>
+<th data-key="rule">Rule</th>
+<th data-key="category">Category</th>
+<th data-key="message">Message</th>
+</tr>
+</thead>
+<tbody></tbody>
+</table>
+<p id="pages"></p>
+
+<h2>Rules</h2>
+<dl>
+{{- range .Rules}}
+<dt id="rule-{{.Name}}"><a href="{{.URL}}">{{.Name}}</a></dt>
+<dd>{{.Description}}</dd>
+{{- end}}
+</dl>
+
+<script type="application/json" id="revive-failures">{{.Failures}}</script>
+<script>
+(function () {
+  "use strict";
+  var pageSize = 200;
+  var failures = JSON.parse(document.getElementById("revive-failures").textContent);
+  var shown = failures, page = 0, sortKey = "", sortDir = 1;
+  var tbody = document.querySelector("#failures tbody");
+  var headers = document.querySelectorAll("#failures th");
+
+  function element(tag, className, text) {
+    var e = document.createElement(tag);
+    if (className) { e.className = className; }
+    if (text !== undefined) { e.textContent = text; }
+    return e;
+  }
+
+  function source(failure) {
+    var pre = element("pre", "source");
+    failure.source.forEach(function (row) {
+      var line = element("span", "line");
+      line.appendChild(element("span", "number", String(row.n)));
+      line.appendChild(document.createTextNode(row.t[0]));
+      if (row.t[1]) { line.appendChild(element("mark", "", row.t[1])); }
+      line.appendChild(document.createTextNode(row.t[2]));
+      pre.appendChild(line);
+    });
+    return pre;
+  }
+
+  function toggle(tr, failure) {
+    var next = tr.nextSibling;
+    if (next && next.className === "excerpt") {
+      tbody.removeChild(next);
+      return;
+    }
+    var row = element("tr", "excerpt");
+    var td = element("td");
+    td.colSpan = 6;
+    td.appendChild(failure.source ? source(failure) : element("em", "", "no source available"));
+    row.appendChild(td);
+    tbody.insertBefore(row, next);
+  }
+
+  function render() {
+    var pages = Math.max(1, Math.ceil(shown.length / pageSize));
+    page = Math.min(page, pages - 1);
+    tbody.textContent = "";
+    shown.slice(page * pageSize, (page + 1) * pageSize).forEach(function (failure) {
+      var tr = element("tr", "failure");
+      tr.appendChild(element("td", "mono", failure.path));
+      tr.appendChild(element("td", "mono", failure.line + ":" + failure.column));
+      tr.appendChild(element("td", failure.severity, failure.severity));
+      var rule = element("td");
+      var link = element("a", "", failure.rule);
+      link.href = "#rule-" + failure.rule;
+      link.addEventListener("click", function (e) { e.stopPropagation(); });
+      rule.appendChild(link);
+      tr.appendChild(rule);
+      tr.appendChild(element("td", "", failure.category));
+      tr.appendChild(element("td", "", failure.message));
+      tr.addEventListener("click", function () { toggle(tr, failure); });
+      tbody.appendChild(tr);
+    });
+    document.getElementById("count").textContent = shown.length + " of " + failures.length + " failures";
+
+    var nav = document.getElementById("pages");
+    nav.textContent = "";
+    if (pages === 1) { return; }
+    [["«", 0], ["‹", page - 1], ["›", page + 1], ["»", pages - 1]].forEach(function (b, i) {
+      var button = element("button", "", b[0]);
+      button.disabled = b[1] < 0 || b[1] >= pages || b[1] === page;
+      button.addEventListener("click", function () { page = b[1]; render(); });
+      nav.appendChild(button);
+      if (i === 1) { nav.appendChild(document.createTextNode(" page " + (page + 1) + " of " + pages + " ")); }
+    });
+  }
+
+  function compare(a, b) {
+    var x = a[sortKey], y = b[sortKey];
+    if (sortKey === "line") {
+      x = a.line * 1e6 + a.column;
+      y = b.line * 1e6 + b.column;
+    }
+    return (x < y ? -1 : x > y ? 1 : 0) * sortDir;
+  }
+
+  function update() {
+    var query = document.getElementById("filter").value.toLowerCase();
+    shown = !query ? failures.slice() : failures.filter(function (f) {
+      return [f.path, f.rule, f.category, f.severity, f.message].join("\n").toLowerCase().indexOf(query) >= 0;
+    });
+    if (sortKey) { shown.sort(compare); }
+    render();
+  }
+
+  headers.forEach(function (th) {
+    th.addEventListener("click", function () {
+      sortDir = sortKey === th.dataset.key ? -sortDir : 1;
+      sortKey = th.dataset.key;
+      headers.forEach(function (h) { h.removeAttribute("data-dir"); });
+      th.setAttribute("data-dir", String(sortDir));
+      page = 0;
+      update();
+    });
+  });
+  var timer;
+  document.getElementById("filter").addEventListener("input", function () {
+    clearTimeout(timer);
+    timer = setTimeout(function () { page = 0; update(); }, 150);
+  });
+  update();
+}());
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,63 @@
+package formatter
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestHTML(t *testing.T) {
+	failure := testFailure("</script><script>alert(1)</script>", "")
+	failure.FileContent = []byte("package pkg\nvar a, bc = 1, 2\n")
+	failures := make(chan lint.Failure, 3)
+	failures <- failure
+	failures <- testFailure("no source", "")
+	failures <- lint.NewInternalFailure("cannot lint")
+	close(failures)
+
+	output, err := (&HTML{}).Format(failures, lint.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(output, "<script>alert(1)") {
+		t.Error("the failure messages are not escaped")
+	}
+	for _, want := range []string{
+		"<strong>2</strong> problems",
+		"<li>cannot lint</li>",
+		`<dt id="rule-rule">`,
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("the report does not contain %q", want)
+		}
+	}
+
+	data := regexp.MustCompile(`(?s)<script type="application/json" id="revive-failures">(.*?)</script>`).FindStringSubmatch(output)
+	if data == nil {
+		t.Fatal("the report does not contain the failures")
+	}
+	var got []htmlFailure
+	if err := json.Unmarshal([]byte(data[1]), &got); err != nil {
+		t.Fatalf("invalid failures %q: %v", data[1], err)
+	}
+	want := []htmlFailure{
+		{
+			Rule: "rule", Category: "cat", Severity: lint.SeverityWarning, Path: "pkg/test.go", Line: 2, Column: 5,
+			Message: "</script><script>alert(1)</script>",
+			Source: []htmlSourceRow{
+				{Number: 1, Text: [3]string{"package pkg", "", ""}},
+				{Number: 2, Text: [3]string{"var ", "a, bc", " = 1, 2"}},
+				{Number: 3, Text: [3]string{"", "", ""}},
+			},
+		},
+		{Rule: "rule", Category: "cat", Severity: lint.SeverityWarning, Path: "pkg/test.go", Line: 2, Column: 5, Message: "no source"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got failures %+v, want %+v", got, want)
+	}
+}
@@ -2,10 +2,16 @@ package formatter
 
 import (
 	"encoding/json"
+	"go/token"
+	"io"
 
 	"github.com/mgechev/revive/lint"
 )
 
+// JSONSchemaVersion is the version of the schema of the reports of the json and ndjson formatters.
+// It is incremented on changes that are not backward compatible.
+const JSONSchemaVersion = 1
+
 // JSON is an implementation of the Formatter interface
 // which formats the errors to JSON.
 type JSON struct {
@@ -17,24 +23,152 @@ func (*JSON) Name() string {
 	return "json"
 }
 
-// jsonObject defines a JSON object of an failure.
-type jsonObject struct {
-	Severity     lint.Severity
-	lint.Failure `json:",inline"`
+// WritesOwners tells that the formatter writes the owners of the failures.
+func (*JSON) WritesOwners() bool {
+	return true
+}
+
+// jsonReport is the header of the JSON report, the failures follow it.
+type jsonReport struct {
+	SchemaVersion int     `json:"schemaVersion"`
+	Revive        runInfo `json:"revive"`
+}
+
+// jsonFailure defines the JSON object of a failure.
+type jsonFailure struct {
+	Rule           string               `json:"rule"`
+	Category       lint.FailureCategory `json:"category"`
+	Severity       lint.Severity        `json:"severity"`
+	Confidence     float64              `json:"confidence"`
+	Message        string               `json:"message"`
+	Path           string               `json:"path"`
+	AbsolutePath   string               `json:"absolutePath"`
+	Start          jsonPosition         `json:"start"`
+	End            jsonPosition         `json:"end"`
+	SuggestedFixes []jsonFix            `json:"suggestedFixes,omitempty"`
+	Related        []jsonRelated        `json:"related,omitempty"`
+	Owners         []string             `json:"owners,omitempty"`
+	Fingerprint    string               `json:"fingerprint"`
+}
+
+// jsonPosition defines the JSON object of a position in a file.
+type jsonPosition struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+	// Offset is the byte offset of the position, starting at 0.
+	Offset int `json:"offset"`
+}
+
+// jsonFix defines the JSON object of a suggested fix.
+type jsonFix struct {
+	// Line is the line to replace.
+	Line int `json:"line"`
+	// Replacement is the new content of the line.
+	Replacement string `json:"replacement"`
+}
+
+// jsonRelated defines the JSON object of a location related to a failure.
+type jsonRelated struct {
+	Message string       `json:"message"`
+	Path    string       `json:"path"`
+	Start   jsonPosition `json:"start"`
+	End     jsonPosition `json:"end"`
+}
+
+func newJSONFailure(config lint.Config, failure lint.Failure) jsonFailure {
+	position := func(p token.Position) jsonPosition {
+		return jsonPosition{Line: p.Line, Column: p.Column, Offset: p.Offset}
+	}
+
+	var fixes []jsonFix
+	if failure.ReplacementLine != "" {
+		fixes = []jsonFix{{Line: failure.Position.Start.Line, Replacement: failure.ReplacementLine}}
+	}
+
+	var related []jsonRelated
+	for _, location := range failure.Related {
+		related = append(related, jsonRelated{
+			Message: location.Message,
+			Path:    relativePath(location.Position.Start.Filename),
+			Start:   position(location.Position.Start),
+			End:     position(location.Position.End),
+		})
+	}
+
+	return jsonFailure{
+		Rule:           failure.RuleName,
+		Category:       failure.Category,
+		Severity:       severity(config, failure),
+		Confidence:     failure.Confidence,
+		Message:        failure.Failure,
+		Path:           relativePath(failure.Filename()),
+		AbsolutePath:   absolutePath(failure.Filename()),
+		Start:          position(failure.Position.Start),
+		End:            position(failure.Position.End),
+		SuggestedFixes: fixes,
+		Related:        related,
+		Owners:         failure.Owners,
+		Fingerprint:    fingerprint(failure),
+	}
 }
 
 // Format formats the failures gotten from the lint.
-func (*JSON) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
-	var slice []jsonObject
-	for failure := range failures {
-		obj := jsonObject{}
-		obj.Severity = severity(config, failure)
-		obj.Failure = failure
-		slice = append(slice, obj)
-	}
-	result, err := json.Marshal(slice)
+func (f *JSON) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (*JSON) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	header, err := json.Marshal(jsonReport{SchemaVersion: JSONSchemaVersion, Revive: newRunInfo(config)})
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	// the failures are added to the header object
+	header = append(header[:len(header)-1], `,"failures":[`...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
 	}
-	return string(result), err
+
+	return &jsonWriter{w: w, config: config, owners: map[string]*ownerCounts{}}, nil
+}
+
+// jsonWriter writes the failures as the elements of the failures array of the report,
+// followed by the numbers of failures by owner, if any.
+type jsonWriter struct {
+	w      io.Writer
+	config lint.Config
+	count  int
+	owners map[string]*ownerCounts
+}
+
+func (fw *jsonWriter) Failure(failure lint.Failure) error {
+	jsonFailure := newJSONFailure(fw.config, failure)
+	addOwnerCounts(fw.owners, failure, jsonFailure.Severity)
+	result, err := json.Marshal(jsonFailure)
+	if err != nil {
+		return err
+	}
+
+	if fw.count > 0 {
+		result = append([]byte{','}, result...)
+	}
+	fw.count++
+
+	_, err = fw.w.Write(result)
+	return err
+}
+
+func (fw *jsonWriter) End() error {
+	end := []byte("]}")
+	if len(fw.owners) > 0 {
+		owners, err := json.Marshal(fw.owners)
+		if err != nil {
+			return err
+		}
+		end = append(append([]byte(`],"owners":`), owners...), '}')
+	}
+
+	_, err := fw.w.Write(end)
+	return err
 }
@@ -0,0 +1,192 @@
+package formatter
+
+import (
+	"bufio"
+	"encoding/json"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestJSON(t *testing.T) {
+	config := lint.Config{Rules: lint.RulesConfig{"rule": {Severity: lint.SeverityError}}}
+	failures := make(chan lint.Failure, 3)
+	failures <- testFailure("test failure", "")
+	failures <- testFailure("fixable failure", "fixed line")
+	withRelated := testFailure("related failure", "")
+	withRelated.Related = []lint.RelatedLocation{{Message: "related", Position: testFailure("", "").Position}}
+	withRelated.Owners = []string{"@org/team", "@user"}
+	failures <- withRelated
+	close(failures)
+
+	output, err := (&JSON{}).Format(failures, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		jsonReport
+		Failures []jsonFailure           `json:"failures"`
+		Owners   map[string]*ownerCounts `json:"owners"`
+	}
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+
+	if got.SchemaVersion != JSONSchemaVersion {
+		t.Errorf("got schema version %d, want %d", got.SchemaVersion, JSONSchemaVersion)
+	}
+	if want := newRunInfo(config); got.Revive != want {
+		t.Errorf("got run info %+v, want %+v", got.Revive, want)
+	}
+	want := []jsonFailure{
+		wantJSONFailure("test failure", nil),
+		wantJSONFailure("fixable failure", []jsonFix{{Line: 2, Replacement: "fixed line"}}),
+		wantJSONFailure("related failure", nil),
+	}
+	want[2].Related = []jsonRelated{{
+		Message: "related",
+		Path:    "pkg/test.go",
+		Start:   jsonPosition{Line: 2, Column: 5, Offset: 20},
+		End:     jsonPosition{Line: 2, Column: 10, Offset: 25},
+	}}
+	want[2].Owners = []string{"@org/team", "@user"}
+	if !reflect.DeepEqual(got.Failures, want) {
+		t.Errorf("got failures %+v, want %+v", got.Failures, want)
+	}
+	wantOwners := map[string]*ownerCounts{"@org/team": {Errors: 1}, "@user": {Errors: 1}}
+	if !reflect.DeepEqual(got.Owners, wantOwners) {
+		t.Errorf("got owners %v, want %v", got.Owners, wantOwners)
+	}
+}
+
+func TestJSON_noFailures(t *testing.T) {
+	failures := make(chan lint.Failure)
+	close(failures)
+
+	output, err := (&JSON{}).Format(failures, lint.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal([]byte(output), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if failures, ok := got["failures"].([]any); !ok || len(failures) != 0 {
+		t.Errorf("got failures %v, want an empty array", got["failures"])
+	}
+	if owners, ok := got["owners"]; ok {
+		t.Errorf("got owners %v, want none", owners)
+	}
+}
+
+func TestNDJSON(t *testing.T) {
+	config := lint.Config{Rules: lint.RulesConfig{"rule": {Severity: lint.SeverityError}}}
+	failures := make(chan lint.Failure, 2)
+	owned := testFailure("test failure", "")
+	owned.Owners = []string{"@org/team"}
+	failures <- owned
+	close(failures)
+
+	output, err := (&NDJSON{}).Format(failures, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var records []string
+	scanner := bufio.NewScanner(strings.NewReader(output))
+	for scanner.Scan() {
+		records = append(records, scanner.Text())
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3: %q", len(records), output)
+	}
+
+	var header ndjsonHeaderRecord
+	if err := json.Unmarshal([]byte(records[0]), &header); err != nil {
+		t.Fatal(err)
+	}
+	wantHeader := ndjsonHeaderRecord{
+		Type:       ndjsonHeader,
+		jsonReport: jsonReport{SchemaVersion: JSONSchemaVersion, Revive: newRunInfo(config)},
+	}
+	if header != wantHeader {
+		t.Errorf("got header %+v, want %+v", header, wantHeader)
+	}
+
+	var failure ndjsonFailureRecord
+	if err := json.Unmarshal([]byte(records[1]), &failure); err != nil {
+		t.Fatal(err)
+	}
+	wantFailure := ndjsonFailureRecord{Type: ndjsonFailure, jsonFailure: wantJSONFailure("test failure", nil)}
+	wantFailure.Owners = []string{"@org/team"}
+	if !reflect.DeepEqual(failure, wantFailure) {
+		t.Errorf("got failure %+v, want %+v", failure, wantFailure)
+	}
+
+	var footer ndjsonFooterRecord
+	if err := json.Unmarshal([]byte(records[2]), &footer); err != nil {
+		t.Fatal(err)
+	}
+	wantFooter := ndjsonFooterRecord{Type: ndjsonFooter, Failures: 1, Errors: 1, Owners: map[string]*ownerCounts{"@org/team": {Errors: 1}}}
+	if !reflect.DeepEqual(footer, wantFooter) {
+		t.Errorf("got footer %+v, want %+v", footer, wantFooter)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	failure := testFailure("test failure", "")
+	moved := failure
+	moved.Position.Start.Line += 10
+	moved.Position.End.Line += 10
+	if fingerprint(failure) != fingerprint(moved) {
+		t.Error("the fingerprint changes with the position of the failure")
+	}
+
+	other := testFailure("other failure", "")
+	if fingerprint(failure) == fingerprint(other) {
+		t.Error("failures with different messages have the same fingerprint")
+	}
+
+	abs := failure
+	abs.Position.Start.Filename = absolutePath(failure.Filename())
+	if fingerprint(failure) != fingerprint(abs) {
+		t.Error("the fingerprint depends on the path being absolute or relative")
+	}
+}
+
+func testFailure(message, replacement string) lint.Failure {
+	return lint.Failure{
+		Failure:  message,
+		RuleName: "rule",
+		Category: "cat",
+		Position: lint.FailurePosition{
+			Start: token.Position{Filename: "pkg/test.go", Offset: 20, Line: 2, Column: 5},
+			End:   token.Position{Filename: "pkg/test.go", Offset: 25, Line: 2, Column: 10},
+		},
+		Confidence:      0.8,
+		ReplacementLine: replacement,
+	}
+}
+
+func wantJSONFailure(message string, fixes []jsonFix) jsonFailure {
+	abs, _ := filepath.Abs(filepath.FromSlash("pkg/test.go"))
+	return jsonFailure{
+		Rule:           "rule",
+		Category:       "cat",
+		Severity:       lint.SeverityError,
+		Confidence:     0.8,
+		Message:        message,
+		Path:           "pkg/test.go",
+		AbsolutePath:   abs,
+		Start:          jsonPosition{Line: 2, Column: 5, Offset: 20},
+		End:            jsonPosition{Line: 2, Column: 10, Offset: 25},
+		SuggestedFixes: fixes,
+		Fingerprint:    fingerprint(testFailure(message, "")),
+	}
+}
@@ -0,0 +1,193 @@
+package formatter
+
+import (
+	"cmp"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// JUnit is an implementation of the Formatter interface
+// which formats the failures as a JUnit XML report.
+//
+// Each package is a test suite, and each rule is a test case for each file of the package.
+// The test case fails if the rule has failures in the file, and passes otherwise.
+// Generated files are reported as skipped test cases, unless rules lint them.
+type JUnit struct {
+	Metadata lint.FormatterMetadata
+}
+
+// Name returns the name of the formatter.
+func (*JUnit) Name() string {
+	return "junit"
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Errors   int              `xml:"errors,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	File      string        `xml:"file,attr,omitempty"`
+	Failure   *junitMessage `xml:"failure"`
+	Error     *junitMessage `xml:"error"`
+	Skipped   *junitMessage `xml:"skipped"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",cdata"`
+}
+
+// junitInternalSuite is the test suite of the errors that prevented linting.
+const junitInternalSuite = "revive"
+
+// Format formats the failures gotten from the lint.
+func (f *JUnit) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (*JUnit) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	return &junitWriter{
+		w:         w,
+		config:    config,
+		testCases: map[junitTestCaseKey]*junitTestCase{},
+	}, nil
+}
+
+type junitTestCaseKey struct {
+	file string
+	rule string
+}
+
+// junitWriter writes the report at the end, once the handled files are known.
+type junitWriter struct {
+	w         io.Writer
+	config    lint.Config
+	testCases map[junitTestCaseKey]*junitTestCase
+	errors    []lint.Failure
+}
+
+func (fw *junitWriter) Failure(failure lint.Failure) error {
+	if failure.IsInternal() {
+		fw.errors = append(fw.errors, failure)
+		return nil
+	}
+
+	rule := failure.RuleName
+	if rule == "" {
+		rule = string(failure.Category)
+	}
+	testCase := fw.testCase(relativePath(failure.Filename()), rule)
+	if testCase.Failure == nil {
+		testCase.Failure = &junitMessage{Message: failure.Failure, Type: string(severity(fw.config, failure))}
+	}
+	if testCase.Failure.Text != "" {
+		testCase.Failure.Text += "\n"
+	}
+	testCase.Failure.Text += fmt.Sprintf("%s:%d:%d: %s", testCase.File, failure.Position.Start.Line, failure.Position.Start.Column, failure.Failure)
+	return nil
+}
+
+// File adds the passed test cases of the rules linting the file without failures in it.
+func (fw *junitWriter) File(report lint.FileReport) error {
+	file := relativePath(report.Name)
+	switch {
+	case report.Generated:
+		fw.testCase(file, "generated").Skipped = &junitMessage{Message: "generated file"}
+	case report.Invalid:
+		// the file has an invalid file failure
+	default:
+		for _, name := range report.Rules {
+			fw.testCase(file, name)
+		}
+	}
+	return nil
+}
+
+func (fw *junitWriter) testCase(file, rule string) *junitTestCase {
+	key := junitTestCaseKey{file: file, rule: rule}
+	testCase, ok := fw.testCases[key]
+	if !ok {
+		testCase = &junitTestCase{Name: rule, ClassName: strings.TrimSuffix(file, ".go"), File: file}
+		fw.testCases[key] = testCase
+	}
+	return testCase
+}
+
+func (fw *junitWriter) End() error {
+	report := junitTestSuites{Name: "revive"}
+	suites := map[string]*junitTestSuite{}
+	addTestCase := func(suiteName string, testCase junitTestCase) {
+		suite, ok := suites[suiteName]
+		if !ok {
+			suite = &junitTestSuite{Name: suiteName}
+			suites[suiteName] = suite
+		}
+		suite.Tests++
+		switch {
+		case testCase.Failure != nil:
+			suite.Failures++
+		case testCase.Error != nil:
+			suite.Errors++
+		case testCase.Skipped != nil:
+			suite.Skipped++
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	for key, testCase := range fw.testCases {
+		addTestCase(path.Dir(key.file), *testCase)
+	}
+	for _, failure := range fw.errors {
+		addTestCase(junitInternalSuite, junitTestCase{
+			Name:      junitInternalSuite,
+			ClassName: junitInternalSuite,
+			Error:     &junitMessage{Message: failure.Failure},
+		})
+	}
+
+	for _, suite := range suites {
+		slices.SortFunc(suite.TestCases, func(a, b junitTestCase) int {
+			return cmp.Or(strings.Compare(a.File, b.File), strings.Compare(a.Name, b.Name))
+		})
+		report.Tests += suite.Tests
+		report.Failures += suite.Failures
+		report.Errors += suite.Errors
+		report.Skipped += suite.Skipped
+		report.Suites = append(report.Suites, *suite)
+	}
+	slices.SortFunc(report.Suites, func(a, b junitTestSuite) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	if _, err := io.WriteString(fw.w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(fw.w)
+	enc.Indent("", "  ")
+	return enc.Encode(report)
+}
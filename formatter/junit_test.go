@@ -0,0 +1,86 @@
+package formatter
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestJUnit_files(t *testing.T) {
+	config := lint.Config{
+		Rules: lint.RulesConfig{
+			"rule":       {},
+			"other-rule": {},
+		},
+	}
+	var sb strings.Builder
+	fw, err := (&JUnit{}).Begin(&sb, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, failure := range []lint.Failure{
+		testFailure("test failure", ""),
+		testFailure("another failure", ""),
+		lint.NewInternalFailure("cannot lint"),
+	} {
+		if err := fw.Failure(failure); err != nil {
+			t.Fatal(err)
+		}
+	}
+	fileWriter, ok := fw.(lint.FileWriter)
+	if !ok {
+		t.Fatal("the JUnit formatter does not implement lint.FileWriter")
+	}
+	for _, report := range []lint.FileReport{
+		{Name: "pkg/test.go", Rules: []string{"rule", "other-rule", "security-rule"}},
+		{Name: "pkg/clean.go", Rules: []string{"rule", "other-rule", "security-rule"}},
+		{Name: "pkg/generated.go", Generated: true},
+		{Name: "pkg/linted.pb.go", GeneratedLinted: true, Rules: []string{"security-rule"}},
+	} {
+		if err := fileWriter.File(report); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := fw.End(); err != nil {
+		t.Fatal(err)
+	}
+
+	var got junitTestSuites
+	if err := xml.Unmarshal([]byte(sb.String()), &got); err != nil {
+		t.Fatalf("invalid XML %q: %v", sb.String(), err)
+	}
+	if got.Tests != 9 || got.Failures != 1 || got.Errors != 1 || got.Skipped != 1 {
+		t.Errorf("got %d tests, %d failures, %d errors and %d skipped, want 9, 1, 1 and 1", got.Tests, got.Failures, got.Errors, got.Skipped)
+	}
+	if len(got.Suites) != 2 || got.Suites[0].Name != "pkg" || got.Suites[1].Name != junitInternalSuite {
+		t.Fatalf("got suites %+v, want pkg and %s", got.Suites, junitInternalSuite)
+	}
+
+	var testCases []string
+	for _, testCase := range got.Suites[0].TestCases {
+		status := "passed"
+		switch {
+		case testCase.Failure != nil:
+			status = "failed: " + testCase.Failure.Text
+		case testCase.Skipped != nil:
+			status = "skipped"
+		}
+		testCases = append(testCases, testCase.File+" "+testCase.Name+" "+status)
+	}
+	want := []string{
+		"pkg/clean.go other-rule passed",
+		"pkg/clean.go rule passed",
+		"pkg/clean.go security-rule passed",
+		"pkg/generated.go generated skipped",
+		"pkg/linted.pb.go security-rule passed",
+		"pkg/test.go other-rule passed",
+		"pkg/test.go rule failed: pkg/test.go:2:5: test failure\npkg/test.go:2:5: another failure",
+		"pkg/test.go security-rule passed",
+	}
+	if strings.Join(testCases, "\n") != strings.Join(want, "\n") {
+		t.Errorf("got test cases\n%s\nwant\n%s", strings.Join(testCases, "\n"), strings.Join(want, "\n"))
+	}
+}
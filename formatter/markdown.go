@@ -0,0 +1,253 @@
+package formatter
+
+import (
+	"cmp"
+	"fmt"
+	"html"
+	"io"
+	"maps"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// Markdown is an implementation of the Formatter interface
+// which formats the failures as a Markdown report, like a comment of a pull request.
+//
+// The report starts with the totals by severity and rule, followed by a collapsible section per file.
+// The links to the failures and the size of the report can be set in the [formatter.markdown] section
+// of the configuration:
+//
+//	[formatter.markdown]
+//	url = "https://github.com/{repo}/blob/{sha}/{path}#L{line}"
+//	repo = "$GITHUB_REPOSITORY"
+//	sha = "$GITHUB_SHA"
+//	max-size = 65000
+type Markdown struct {
+	Metadata lint.FormatterMetadata
+}
+
+// Name returns the name of the formatter.
+func (*Markdown) Name() string {
+	return "markdown"
+}
+
+// WritesOwners tells that the formatter writes the owners of the failures.
+func (*Markdown) WritesOwners() bool {
+	return true
+}
+
+// markdownDefaultMaxSize is the default maximum size of the report, in bytes:
+// GitHub limits the size of the comments to 65536 characters.
+const markdownDefaultMaxSize = 65000
+
+// markdownMoreSize is the room kept for the note about the failures left out of the report.
+const markdownMoreSize = 100
+
+// Format formats the failures gotten from the lint.
+func (f *Markdown) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (f *Markdown) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	fw := &markdownWriter{w: w, config: config, maxSize: markdownDefaultMaxSize, files: map[string][]lint.Failure{}, owners: map[string]*ownerCounts{}}
+
+	options := config.Formatters[f.Name()]
+	var err error
+	if fw.url, err = markdownStringOption(options, "url"); err != nil {
+		return nil, err
+	}
+	if fw.repo, err = markdownStringOption(options, "repo"); err != nil {
+		return nil, err
+	}
+	if fw.sha, err = markdownStringOption(options, "sha"); err != nil {
+		return nil, err
+	}
+	if value, ok := options["max-size"]; ok {
+		size, ok := value.(int64)
+		if !ok || size <= markdownMoreSize {
+			return nil, fmt.Errorf("invalid max-size option: expected an integer greater than %d, got %v", markdownMoreSize, value)
+		}
+		fw.maxSize = int(size)
+	}
+
+	return fw, nil
+}
+
+// markdownStringOption returns the string option of the given name, with the environment variables it references expanded.
+func markdownStringOption(options lint.FormatterConfig, name string) (string, error) {
+	value, ok := options[name]
+	if !ok {
+		return "", nil
+	}
+	str, ok := value.(string)
+	if !ok {
+		return "", fmt.Errorf("invalid %s option: expected a string, got %T", name, value)
+	}
+	return os.ExpandEnv(str), nil
+}
+
+// markdownWriter writes the report at the end, once the totals are known.
+type markdownWriter struct {
+	w        io.Writer
+	config   lint.Config
+	url      string
+	repo     string
+	sha      string
+	maxSize  int
+	internal []string
+	files    map[string][]lint.Failure
+	owners   map[string]*ownerCounts
+}
+
+func (fw *markdownWriter) Failure(failure lint.Failure) error {
+	if failure.IsInternal() {
+		fw.internal = append(fw.internal, failure.Failure)
+		return nil
+	}
+
+	path := relativePath(failure.Filename())
+	fw.files[path] = append(fw.files[path], failure)
+	addOwnerCounts(fw.owners, failure, severity(fw.config, failure))
+	return nil
+}
+
+func (fw *markdownWriter) End() error {
+	var report strings.Builder
+	report.WriteString("### revive\n\n")
+	for _, message := range fw.internal {
+		fmt.Fprintf(&report, "> [!CAUTION]\n> revive could not lint the code: %s\n\n", markdownEscape(message))
+	}
+
+	errors, warnings := map[string]int{}, map[string]int{}
+	for _, failures := range fw.files {
+		for _, failure := range failures {
+			if severity(fw.config, failure) == lint.SeverityError {
+				errors[failure.RuleName]++
+			} else {
+				warnings[failure.RuleName]++
+			}
+		}
+	}
+	totals := map[string]int{}
+	totalErrors, totalWarnings := 0, 0
+	for name, count := range errors {
+		totals[name] += count
+		totalErrors += count
+	}
+	for name, count := range warnings {
+		totals[name] += count
+		totalWarnings += count
+	}
+	if len(totals) == 0 {
+		report.WriteString("No problems found.\n")
+		_, err := io.WriteString(fw.w, report.String())
+		return err
+	}
+
+	report.WriteString("| Severity | Failures |\n| --- | ---: |\n")
+	fmt.Fprintf(&report, "| :x: error | %d |\n| :warning: warning | %d |\n| **total** | **%d** |\n\n", totalErrors, totalWarnings, totalErrors+totalWarnings)
+	report.WriteString("| Rule | Errors | Warnings |\n| --- | ---: | ---: |\n")
+	for _, entry := range statistics(totals) {
+		fmt.Fprintf(&report, "| [%s](%s) | %d | %d |\n", entry.name, ruleDescriptionURL(entry.name), errors[entry.name], warnings[entry.name])
+	}
+	report.WriteString("\n")
+	if len(fw.owners) > 0 {
+		report.WriteString("| Owner | Errors | Warnings |\n| --- | ---: | ---: |\n")
+		for _, entry := range statistics(ownerTotals(fw.owners)) {
+			counts := fw.owners[entry.name]
+			fmt.Fprintf(&report, "| %s | %d | %d |\n", markdownEscape(entry.name), counts.Errors, counts.Warnings)
+		}
+		report.WriteString("\n")
+	}
+
+	left := totalErrors + totalWarnings
+	for _, path := range slices.Sorted(maps.Keys(fw.files)) {
+		failures := fw.files[path]
+		slices.SortStableFunc(failures, func(a, b lint.Failure) int {
+			return cmp.Or(cmp.Compare(a.Position.Start.Line, b.Position.Start.Line), cmp.Compare(a.Position.Start.Column, b.Position.Start.Column))
+		})
+
+		section := fmt.Sprintf("<details>\n<summary><code>%s</code> (%d)</summary>\n\n", html.EscapeString(path), len(failures))
+		const sectionEnd = "\n</details>\n\n"
+		written := 0
+		for _, failure := range failures {
+			line := fw.failureLine(path, failure)
+			if report.Len()+len(section)+len(line)+len(sectionEnd)+markdownMoreSize > fw.maxSize {
+				break
+			}
+			section += line
+			written++
+		}
+		if written > 0 {
+			report.WriteString(section + sectionEnd)
+		}
+		left -= written
+		if written < len(failures) {
+			break
+		}
+	}
+	switch {
+	case left == 1:
+		report.WriteString("_1 more failure is not shown._\n")
+	case left > 1:
+		fmt.Fprintf(&report, "_%d more failures are not shown._\n", left)
+	}
+
+	_, err := io.WriteString(fw.w, report.String())
+	return err
+}
+
+// failureLine returns the item of the list of the failures of a file.
+func (fw *markdownWriter) failureLine(path string, failure lint.Failure) string {
+	emoji := ":warning:"
+	if severity(fw.config, failure) == lint.SeverityError {
+		emoji = ":x:"
+	}
+
+	line := failure.Position.Start.Line
+	location := fmt.Sprintf("`%s:%d`", path, line)
+	if fw.url != "" {
+		location = fmt.Sprintf("[%s](%s)", location, fw.link(path, line))
+	}
+
+	return fmt.Sprintf("- %s %s [%s](%s): %s\n", emoji, location, failure.RuleName, ruleDescriptionURL(failure.RuleName), markdownEscape(failure.Failure))
+}
+
+// link returns the URL of the line of the file, from the URL template of the options.
+func (fw *markdownWriter) link(path string, line int) string {
+	segments := strings.Split(path, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+
+	return strings.NewReplacer(
+		"{repo}", fw.repo,
+		"{sha}", fw.sha,
+		"{path}", strings.Join(segments, "/"),
+		"{line}", strconv.Itoa(line),
+	).Replace(fw.url)
+}
+
+var markdownReplacer = strings.NewReplacer(
+	`\`, `\\`,
+	"`", "\\`",
+	"*", `\*`,
+	"_", `\_`,
+	"[", `\[`,
+	"]", `\]`,
+	"|", `\|`,
+	"<", "&lt;",
+	">", "&gt;",
+	"\n", " ",
+)
+
+// markdownEscape escapes the text so that it is not interpreted as Markdown or HTML.
+func markdownEscape(text string) string {
+	return markdownReplacer.Replace(text)
+}
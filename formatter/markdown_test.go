@@ -0,0 +1,92 @@
+package formatter
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestMarkdown_links(t *testing.T) {
+	t.Setenv("TEST_MARKDOWN_SHA", "0123abc")
+	config := lint.Config{Formatters: lint.FormattersConfig{"markdown": {
+		"url":  "https://example.com/{repo}/blob/{sha}/{path}#L{line}",
+		"repo": "org/repo",
+		"sha":  "$TEST_MARKDOWN_SHA",
+	}}}
+	failure := testFailure("use *pointers* | <b>", "")
+	failure.Position.Start.Filename = "pkg/my file.go"
+	failures := make(chan lint.Failure, 1)
+	failures <- failure
+	close(failures)
+
+	output, err := (&Markdown{}).Format(failures, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "- :warning: [`pkg/my file.go:2`](https://example.com/org/repo/blob/0123abc/pkg/my%20file.go#L2) [rule](https://revive.run/r#rule): use \\*pointers\\* \\| &lt;b&gt;\n"
+	if !strings.Contains(output, want) {
+		t.Errorf("got %q, want it to contain %q", output, want)
+	}
+}
+
+func TestMarkdown_owners(t *testing.T) {
+	failures := make(chan lint.Failure, 3)
+	for _, owners := range [][]string{{"@org/team"}, {"@org/team", "@user"}, nil} {
+		failure := testFailure("test failure", "")
+		failure.Owners = owners
+		failures <- failure
+	}
+	close(failures)
+
+	output, err := (&Markdown{}).Format(failures, lint.Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "| Owner | Errors | Warnings |\n| --- | ---: | ---: |\n| @org/team | 0 | 2 |\n| @user | 0 | 1 |\n"
+	if !strings.Contains(output, want) {
+		t.Errorf("got %q, want it to contain %q", output, want)
+	}
+}
+
+func TestMarkdown_maxSize(t *testing.T) {
+	const maxSize = 1000
+	config := lint.Config{Formatters: lint.FormattersConfig{"markdown": {"max-size": int64(maxSize)}}}
+	failures := make(chan lint.Failure, 100)
+	for range 100 {
+		failures <- testFailure("test failure", "")
+	}
+	close(failures)
+
+	output, err := (&Markdown{}).Format(failures, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(output) > maxSize {
+		t.Errorf("got a report of %d bytes, want at most %d", len(output), maxSize)
+	}
+	shown := strings.Count(output, "- :warning:")
+	if shown == 0 || shown == 100 {
+		t.Fatalf("got %d failures in the report, want some of them", shown)
+	}
+	if !strings.HasSuffix(output, "</details>\n\n_"+strconv.Itoa(100-shown)+" more failures are not shown._\n") {
+		t.Errorf("the report does not end with the number of failures left out: %q", output)
+	}
+}
+
+func TestMarkdown_invalidOptions(t *testing.T) {
+	for _, options := range []lint.FormatterConfig{
+		{"url": 1},
+		{"max-size": "big"},
+		{"max-size": int64(10)},
+	} {
+		config := lint.Config{Formatters: lint.FormattersConfig{"markdown": options}}
+		if _, err := (&Markdown{}).Begin(&strings.Builder{}, config); err == nil {
+			t.Errorf("expected an error for the options %v", options)
+		}
+	}
+}
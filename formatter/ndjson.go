@@ -1,8 +1,8 @@
 package formatter
 
 import (
-	"bytes"
 	"encoding/json"
+	"io"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -18,18 +18,78 @@ func (*NDJSON) Name() string {
 	return "ndjson"
 }
 
+// WritesOwners tells that the formatter writes the owners of the failures.
+func (*NDJSON) WritesOwners() bool {
+	return true
+}
+
+// Types of the records of the NDJSON stream.
+const (
+	ndjsonHeader  = "header"
+	ndjsonFailure = "failure"
+	ndjsonFooter  = "footer"
+)
+
+// ndjsonHeaderRecord is the first record of the stream.
+type ndjsonHeaderRecord struct {
+	Type string `json:"type"`
+	jsonReport
+}
+
+// ndjsonFailureRecord is the record of a failure.
+type ndjsonFailureRecord struct {
+	Type string `json:"type"`
+	jsonFailure
+}
+
+// ndjsonFooterRecord is the last record of the stream.
+type ndjsonFooterRecord struct {
+	Type     string `json:"type"`
+	Failures int    `json:"failures"`
+	Errors   int    `json:"errors"`
+	Warnings int    `json:"warnings"`
+	// Owners are the numbers of failures by owner, from the CODEOWNERS file.
+	Owners map[string]*ownerCounts `json:"owners,omitempty"`
+}
+
 // Format formats the failures gotten from the lint.
-func (*NDJSON) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
-	var buf bytes.Buffer
-	enc := json.NewEncoder(&buf)
-	for failure := range failures {
-		obj := jsonObject{}
-		obj.Severity = severity(config, failure)
-		obj.Failure = failure
-		err := enc.Encode(obj)
-		if err != nil {
-			return "", err
-		}
+func (f *NDJSON) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (*NDJSON) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	enc := json.NewEncoder(w)
+	header := ndjsonHeaderRecord{
+		Type:       ndjsonHeader,
+		jsonReport: jsonReport{SchemaVersion: JSONSchemaVersion, Revive: newRunInfo(config)},
+	}
+	if err := enc.Encode(header); err != nil {
+		return nil, err
 	}
-	return buf.String(), nil
+
+	return &ndjsonWriter{enc: enc, config: config, footer: ndjsonFooterRecord{Type: ndjsonFooter, Owners: map[string]*ownerCounts{}}}, nil
+}
+
+type ndjsonWriter struct {
+	enc    *json.Encoder
+	config lint.Config
+	footer ndjsonFooterRecord
+}
+
+func (fw *ndjsonWriter) Failure(failure lint.Failure) error {
+	record := ndjsonFailureRecord{Type: ndjsonFailure, jsonFailure: newJSONFailure(fw.config, failure)}
+	fw.footer.Failures++
+	if record.Severity == lint.SeverityError {
+		fw.footer.Errors++
+	} else {
+		fw.footer.Warnings++
+	}
+	addOwnerCounts(fw.footer.Owners, failure, record.Severity)
+
+	return fw.enc.Encode(record)
+}
+
+func (fw *ndjsonWriter) End() error {
+	return fw.enc.Encode(fw.footer)
 }
@@ -0,0 +1,34 @@
+package formatter
+
+import (
+	"github.com/mgechev/revive/lint"
+)
+
+// ownerCounts are the numbers of failures of the files of an owner, from the CODEOWNERS file.
+type ownerCounts struct {
+	Errors   int `json:"errors"`
+	Warnings int `json:"warnings"`
+}
+
+// addOwnerCounts counts the failure for each of its owners.
+func addOwnerCounts(counts map[string]*ownerCounts, failure lint.Failure, sev lint.Severity) {
+	for _, owner := range failure.Owners {
+		if counts[owner] == nil {
+			counts[owner] = &ownerCounts{}
+		}
+		if sev == lint.SeverityError {
+			counts[owner].Errors++
+		} else {
+			counts[owner].Warnings++
+		}
+	}
+}
+
+// ownerTotals returns the total number of failures of each owner.
+func ownerTotals(counts map[string]*ownerCounts) map[string]int {
+	totals := make(map[string]int, len(counts))
+	for owner, c := range counts {
+		totals[owner] = c.Errors + c.Warnings
+	}
+	return totals
+}
@@ -2,7 +2,7 @@ package formatter
 
 import (
 	"fmt"
-	"strings"
+	"io"
 
 	"github.com/mgechev/revive/lint"
 )
@@ -19,10 +19,13 @@ func (*Plain) Name() string {
 }
 
 // Format formats the failures gotten from the lint.
-func (*Plain) Format(failures <-chan lint.Failure, _ lint.Config) (string, error) {
-	var sb strings.Builder
-	for failure := range failures {
-		sb.WriteString(fmt.Sprintf("%v: %s %s\n", failure.Position.Start, failure.Failure, ruleDescriptionURL(failure.RuleName)))
-	}
-	return sb.String(), nil
+func (f *Plain) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (*Plain) Begin(w io.Writer, _ lint.Config) (lint.FailureWriter, error) {
+	return &lineWriter{w: w, line: func(failure lint.Failure) string {
+		return fmt.Sprintf("%v: %s %s", failure.Position.Start, failure.Failure, ruleDescriptionURL(failure.RuleName))
+	}}, nil
 }
@@ -0,0 +1,110 @@
+package formatter
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+const reviveModulePath = "github.com/mgechev/revive"
+
+// Version is the version of revive written in the metadata of the reports, like the header of the ndjson report.
+// It defaults to the version of the revive module found in the build info of the binary.
+var Version = moduleVersion()
+
+func moduleVersion() string {
+	bi, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "dev"
+	}
+
+	version := bi.Main.Version
+	if bi.Main.Path != reviveModulePath {
+		// revive is used as a library
+		version = ""
+		for _, dep := range bi.Deps {
+			if dep.Path == reviveModulePath {
+				version = dep.Version
+				break
+			}
+		}
+	}
+
+	if version == "" || version == "(devel)" {
+		return "dev"
+	}
+	return strings.TrimPrefix(version, "v")
+}
+
+// runInfo is the metadata of a run of revive.
+type runInfo struct {
+	// ReviveVersion is the version of revive.
+	ReviveVersion string `json:"reviveVersion"`
+	// GoVersion is the version of Go revive was built with.
+	GoVersion string `json:"goVersion"`
+	// ConfigHash identifies the configuration of the run: it is the SHA-256 of the configuration encoded in JSON.
+	ConfigHash string `json:"configHash"`
+}
+
+func newRunInfo(config lint.Config) runInfo {
+	var configHash string
+	if encoded, err := json.Marshal(config); err == nil {
+		sum := sha256.Sum256(encoded)
+		configHash = hex.EncodeToString(sum[:])
+	}
+
+	return runInfo{
+		ReviveVersion: Version,
+		GoVersion:     runtime.Version(),
+		ConfigHash:    configHash,
+	}
+}
+
+// relativePath returns the slash-separated path of the file, relative to the working directory
+// if the file is in it.
+func relativePath(filename string) string {
+	if filename == "" {
+		return ""
+	}
+
+	if filepath.IsAbs(filename) {
+		if wd, err := os.Getwd(); err == nil {
+			rel, err := filepath.Rel(wd, filename)
+			if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+				filename = rel
+			}
+		}
+	}
+
+	return filepath.ToSlash(filepath.Clean(filename))
+}
+
+// absolutePath returns the absolute path of the file.
+func absolutePath(filename string) string {
+	if filename == "" {
+		return ""
+	}
+
+	abs, err := filepath.Abs(filename)
+	if err != nil {
+		return filename
+	}
+	return abs
+}
+
+// fingerprint returns an identifier of the failure that is stable from a run to another:
+// it does not depend on the position of the failure in the file, thus it does not change
+// when lines are added or removed before the failure.
+//
+// Identical failures of a rule in the same file share the same fingerprint.
+func fingerprint(failure lint.Failure) string {
+	sum := sha256.Sum256([]byte(failure.RuleName + "\x00" + relativePath(failure.Filename()) + "\x00" + failure.Failure))
+	return hex.EncodeToString(sum[:])
+}
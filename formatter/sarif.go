@@ -1,12 +1,17 @@
 package formatter
 
 import (
-	"bytes"
 	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 
 	"codeberg.org/chavacava/garif"
 
+	"github.com/mgechev/revive/internal/ruledoc"
 	"github.com/mgechev/revive/lint"
 )
 
@@ -21,52 +26,105 @@ func (*Sarif) Name() string {
 	return "sarif"
 }
 
-const reviveSite = "https://revive.run"
+const (
+	reviveSite = "https://revive.run"
+	// sarifSrcRoot is the base of the URIs of the files in the working directory.
+	sarifSrcRoot = "SRCROOT"
+	// sarifFingerprint is the key of the fingerprint of a failure in the partial fingerprints of a result.
+	sarifFingerprint = "revive/v1"
+)
 
 // Format formats the failures gotten from the lint.
-func (*Sarif) Format(failures <-chan lint.Failure, cfg lint.Config) (string, error) {
-	sarifLog := newReviveRunLog(cfg)
+func (f *Sarif) Format(failures <-chan lint.Failure, cfg lint.Config) (string, error) {
+	return format(f, failures, cfg)
+}
+
+// Begin starts a report written to w.
+func (*Sarif) Begin(w io.Writer, cfg lint.Config) (lint.FailureWriter, error) {
+	return &sarifWriter{w: w, log: newReviveRunLog(cfg)}, nil
+}
 
-	for failure := range failures {
-		sarifLog.addResult(failure)
+// sarifWriter writes the SARIF log, holding the results of the run, at the end.
+type sarifWriter struct {
+	w   io.Writer
+	log *reviveRunLog
+}
+
+func (fw *sarifWriter) Failure(failure lint.Failure) error {
+	if failure.IsInternal() {
+		fw.log.addToolError(failure)
+		return nil
 	}
 
-	buf := new(bytes.Buffer)
-	sarifLog.PrettyWrite(buf)
+	fw.log.addResult(failure)
+	return nil
+}
 
-	return buf.String(), nil
+func (fw *sarifWriter) End() error {
+	fw.log.run.Invocations = []*garif.Invocation{fw.log.invocation}
+	return fw.log.PrettyWrite(fw.w)
 }
 
 type reviveRunLog struct {
 	*garif.LogFile
-	run   *garif.Run
-	rules map[string]lint.RuleConfig
+	run        *garif.Run
+	cfg        lint.Config
+	rules      map[string]bool
+	invocation *garif.Invocation
+	// srcRoot is the directory the relative URIs are based on.
+	srcRoot string
 }
 
 func newReviveRunLog(cfg lint.Config) *reviveRunLog {
-	run := garif.NewRun(garif.NewTool(garif.NewDriver("revive").WithInformationUri(reviveSite)))
+	driver := garif.NewDriver("revive").WithInformationUri(reviveSite)
+	driver.Version = Version
+	run := garif.NewRun(garif.NewTool(driver))
 	log := garif.NewLogFile([]*garif.Run{run}, garif.Version210)
+	log.Schema = "https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/schemas/sarif-schema-2.1.0.json"
 
 	reviveLog := &reviveRunLog{
-		log,
-		run,
-		cfg.Rules,
+		LogFile:    log,
+		run:        run,
+		cfg:        cfg,
+		rules:      map[string]bool{},
+		invocation: garif.NewInvocation(true),
 	}
 
-	reviveLog.addRules(cfg.Rules)
+	if wd, err := os.Getwd(); err == nil {
+		reviveLog.srcRoot = wd
+		run.OriginalUriBaseIds = map[string]*garif.ArtifactLocation{
+			sarifSrcRoot: {Uri: fileURI(wd, true)},
+		}
+	}
+
+	names := make([]string, 0, len(cfg.Rules))
+	for name, ruleCfg := range cfg.Rules {
+		if !ruleCfg.Disabled {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	reviveLog.addRules(names...)
 
 	return reviveLog
 }
 
-func (l *reviveRunLog) addRules(cfg map[string]lint.RuleConfig) {
-	for name, ruleCfg := range cfg {
-		rule := garif.NewRule(name).WithHelpUri(reviveSite + "/r#" + name)
-		setRuleProperties(rule, ruleCfg)
-		driver := l.run.Tool.Driver
+func (l *reviveRunLog) addRules(names ...string) {
+	driver := l.run.Tool.Driver
+	for _, name := range names {
+		if l.rules[name] {
+			continue
+		}
+		l.rules[name] = true
 
-		if driver.Rules == nil {
-			driver.Rules = []*garif.ReportingDescriptor{rule}
-			return
+		rule := garif.NewRule(name).WithHelpUri(ruleDescriptionURL(name))
+		if description, ok := ruledoc.Of(name); ok {
+			rule.ShortDescription = garif.NewMultiformatMessageString(description.Short)
+			rule.FullDescription = garif.NewMultiformatMessageString(description.Full)
+			rule.FullDescription.Markdown = description.FullMarkdown
+		}
+		if ruleCfg, ok := l.cfg.Rules[name]; ok {
+			setRuleProperties(rule, ruleCfg)
 		}
 
 		driver.Rules = append(driver.Rules, rule)
@@ -74,24 +132,118 @@ func (l *reviveRunLog) addRules(cfg map[string]lint.RuleConfig) {
 }
 
 func (l *reviveRunLog) addResult(failure lint.Failure) {
+	l.addRules(failure.RuleName)
+
+	artifact := l.artifactLocation(failure.Filename())
+	location := garif.NewLocation()
+	location.PhysicalLocation = garif.NewPhysicalLocation()
+	location.PhysicalLocation.ArtifactLocation = artifact
+	location.PhysicalLocation.Region = sarifRegion(failure.Position)
+
+	result := garif.NewResult(garif.NewMessageFromText(failure.Failure))
+	result.Locations = append(result.Locations, location)
+	result.RuleId = failure.RuleName
+	result.Level = garif.ResultLevel(severity(l.cfg, failure))
+	result.PartialFingerprints = map[string]string{sarifFingerprint: fingerprint(failure)}
+
+	for i, related := range failure.Related {
+		relatedLocation := garif.NewLocation()
+		// the ids of the locations are positive
+		relatedLocation.Id = i + 1
+		relatedLocation.Message = garif.NewMessageFromText(related.Message)
+		relatedLocation.PhysicalLocation = garif.NewPhysicalLocation()
+		relatedLocation.PhysicalLocation.ArtifactLocation = l.artifactLocation(related.Position.Start.Filename)
+		relatedLocation.PhysicalLocation.Region = sarifRegion(related.Position)
+		result.RelatedLocations = append(result.RelatedLocations, relatedLocation)
+	}
+
+	if failure.ReplacementLine != "" {
+		// without columns, the region spans the whole line
+		deleted := garif.NewRegion()
+		deleted.StartLine = failure.Position.Start.Line
+		replacement := garif.NewReplacement(deleted)
+		replacement.InsertedContent = garif.NewArtifactContent()
+		replacement.InsertedContent.Text = failure.ReplacementLine
+		fix := garif.NewFix(garif.NewArtifactChange(artifact, replacement))
+		fix.Description = garif.NewMessageFromText(fmt.Sprintf("Replace line %d", failure.Position.Start.Line))
+		result.Fixes = append(result.Fixes, fix)
+	}
+
+	l.run.Results = append(l.run.Results, result)
+
+	if result.Level == garif.ResultLevel_Error {
+		l.invocation.ExitCode = l.cfg.ErrorCode
+	} else if l.invocation.ExitCode == 0 {
+		l.invocation.ExitCode = l.cfg.WarningCode
+	}
+}
+
+// addToolError reports an internal failure as an error of the execution of revive.
+func (l *reviveRunLog) addToolError(failure lint.Failure) {
+	notification := garif.NewNotification(garif.NewMessageFromText(failure.Failure))
+	notification.Level = garif.ResultLevel_Error
+	l.invocation.ToolExecutionNotifications = append(l.invocation.ToolExecutionNotifications, notification)
+	l.invocation.ExecutionSuccessful = false
+}
+
+// artifactLocation returns the location of the file, relative to the working directory if the file is in it.
+func (l *reviveRunLog) artifactLocation(filename string) *garif.ArtifactLocation {
+	location := garif.NewArtifactLocation()
+	abs := absolutePath(filename)
+	if l.srcRoot != "" {
+		rel, err := filepath.Rel(l.srcRoot, abs)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			location.Uri = (&url.URL{Path: filepath.ToSlash(rel)}).String()
+			location.UriBaseId = sarifSrcRoot
+			return location
+		}
+	}
+
+	location.Uri = fileURI(abs, false)
+	return location
+}
+
+// fileURI returns the file URI of the absolute path; directory URIs end with a slash.
+func fileURI(path string, isDir bool) string {
+	path = filepath.ToSlash(path)
+	if !strings.HasPrefix(path, "/") {
+		// Windows paths, like C:/dir
+		path = "/" + path
+	}
+	if isDir && !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+// sarifRegion returns the region of the position.
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/os/sarif-v2.1.0-os.html#_Toc34317685
+func sarifRegion(position lint.FailurePosition) *garif.Region {
 	positiveOrZero := func(x int) int {
 		if x > 0 {
 			return x
 		}
 		return 0
 	}
-	position := failure.Position
-	filename := position.Start.Filename
-	line := positiveOrZero(position.Start.Line)     // https://docs.oasis-open.org/sarif/sarif/v2.1.0/csprd01/sarif-v2.1.0-csprd01.html#def_line
-	column := positiveOrZero(position.Start.Column) // https://docs.oasis-open.org/sarif/sarif/v2.1.0/csprd01/sarif-v2.1.0-csprd01.html#def_column
 
-	result := garif.NewResult(garif.NewMessageFromText(failure.Failure))
-	location := garif.NewLocation().WithURI(filename).WithLineColumn(line, column)
-	result.Locations = append(result.Locations, location)
-	result.RuleId = failure.RuleName
-	result.Level = garif.ResultLevel(l.rules[failure.RuleName].Severity)
+	if position.Start.Line <= 0 {
+		return nil
+	}
 
-	l.run.Results = append(l.run.Results, result)
+	region := garif.NewRegion()
+	region.StartLine = position.Start.Line
+	region.StartColumn = positiveOrZero(position.Start.Column)
+
+	endLine := positiveOrZero(position.End.Line)
+	endColumn := positiveOrZero(position.End.Column)
+	startColumn := max(region.StartColumn, 1)
+	if endLine > region.StartLine || (endLine == region.StartLine && endColumn >= startColumn) {
+		region.EndLine = endLine
+		region.EndColumn = endColumn
+	}
+
+	return region
 }
 
 func setRuleProperties(sarifRule *garif.ReportingDescriptor, lintRule lint.RuleConfig) {
@@ -0,0 +1,180 @@
+package formatter
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestSarif(t *testing.T) {
+	config := lint.Config{
+		ErrorCode:   2,
+		WarningCode: 1,
+		Rules: lint.RulesConfig{
+			"rule":          {Severity: lint.SeverityError},
+			"var-naming":    {},
+			"unused-param":  {Arguments: lint.Arguments{"x"}},
+			"disabled-rule": {Disabled: true},
+		},
+	}
+	failures := make(chan lint.Failure, 3)
+	failures <- testFailure("test failure", "")
+	fixable := testFailure("fixable failure", "fixed line")
+	fixable.Related = []lint.RelatedLocation{{Message: "related", Position: testFailure("", "").Position}}
+	failures <- fixable
+	failures <- lint.NewInternalFailure("cannot lint")
+	close(failures)
+
+	output, err := (&Sarif{}).Format(failures, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var log map[string]any
+	if err := json.Unmarshal([]byte(output), &log); err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	validateSarif(t, output)
+
+	run := log["runs"].([]any)[0].(map[string]any)
+
+	var ruleIDs []string
+	for _, rule := range run["tool"].(map[string]any)["driver"].(map[string]any)["rules"].([]any) {
+		ruleIDs = append(ruleIDs, rule.(map[string]any)["id"].(string))
+	}
+	if got, want := strings.Join(ruleIDs, ","), "rule,unused-param,var-naming"; got != want {
+		t.Errorf("got rules %s, want %s", got, want)
+	}
+
+	results := run["results"].([]any)
+	if len(results) != 2 {
+		t.Fatalf("got %d results, want 2", len(results))
+	}
+	result := results[1].(map[string]any)
+	if got := result["level"]; got != "error" {
+		t.Errorf("got level %v, want error", got)
+	}
+	location := result["locations"].([]any)[0].(map[string]any)["physicalLocation"].(map[string]any)
+	artifact := location["artifactLocation"].(map[string]any)
+	if artifact["uri"] != "pkg/test.go" || artifact["uriBaseId"] != sarifSrcRoot {
+		t.Errorf("got artifact location %v, want pkg/test.go relative to %s", artifact, sarifSrcRoot)
+	}
+	region := location["region"].(map[string]any)
+	if fmt.Sprint(region) != "map[endColumn:10 endLine:2 startColumn:5 startLine:2]" {
+		t.Errorf("got region %v", region)
+	}
+	wantFingerprint := fingerprint(testFailure("fixable failure", ""))
+	if got := result["partialFingerprints"].(map[string]any)[sarifFingerprint]; got != wantFingerprint {
+		t.Errorf("got fingerprint %v, want %s", got, wantFingerprint)
+	}
+	related, ok := result["relatedLocations"].([]any)
+	if !ok || len(related) != 1 {
+		t.Fatalf("got related locations %v, want one location", result["relatedLocations"])
+	}
+	if got := related[0].(map[string]any); got["id"] != float64(1) || got["message"].(map[string]any)["text"] != "related" {
+		t.Errorf("got related location %v, want the related location of the failure", got)
+	}
+	fixes, ok := result["fixes"].([]any)
+	if !ok || len(fixes) != 1 {
+		t.Fatalf("got fixes %v, want one fix", result["fixes"])
+	}
+
+	invocation := run["invocations"].([]any)[0].(map[string]any)
+	if invocation["executionSuccessful"] != false || invocation["exitCode"] != float64(2) {
+		t.Errorf("got invocation %v, want an unsuccessful execution with exit code 2", invocation)
+	}
+	notifications := invocation["toolExecutionNotifications"].([]any)
+	if len(notifications) != 1 || notifications[0].(map[string]any)["message"].(map[string]any)["text"] != "cannot lint" {
+		t.Errorf("got tool notifications %v, want the internal failure", notifications)
+	}
+}
+
+func TestSarifRegion(t *testing.T) {
+	tests := []struct {
+		name     string
+		position lint.FailurePosition
+		want     string
+	}{
+		{
+			name:     "no position",
+			position: lint.FailurePosition{},
+			want:     "<nil>",
+		},
+		{
+			name:     "start only",
+			position: position(3, 0, 0, 0),
+			want:     "3:0-0:0",
+		},
+		{
+			name:     "range",
+			position: position(3, 2, 5, 1),
+			want:     "3:2-5:1",
+		},
+		{
+			name:     "end before start",
+			position: position(3, 5, 3, 2),
+			want:     "3:5-0:0",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			region := sarifRegion(tt.position)
+			got := "<nil>"
+			if region != nil {
+				got = fmt.Sprintf("%d:%d-%d:%d", region.StartLine, region.StartColumn, region.EndLine, region.EndColumn)
+			}
+			if got != tt.want {
+				t.Errorf("got %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func position(startLine, startColumn, endLine, endColumn int) lint.FailurePosition {
+	p := lint.FailurePosition{}
+	p.Start.Line, p.Start.Column = startLine, startColumn
+	p.End.Line, p.End.Column = endLine, endColumn
+	return p
+}
+
+// validateSarif validates the SARIF log against the SARIF 2.1.0 JSON schema.
+func validateSarif(t *testing.T, output string) {
+	t.Helper()
+
+	schemaFile, err := os.Open(filepath.Join("testdata", "sarif-schema-2.1.0.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer schemaFile.Close()
+	schemaDoc, err := jsonschema.UnmarshalJSON(schemaFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.AssertFormat()
+	const schemaURL = "https://docs.oasis-open.org/sarif/sarif/v2.1.0/errata01/os/schemas/sarif-schema-2.1.0.json"
+	if err := compiler.AddResource(schemaURL, schemaDoc); err != nil {
+		t.Fatal(err)
+	}
+	schema, err := compiler.Compile(schemaURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	log, err := jsonschema.UnmarshalJSON(strings.NewReader(output))
+	if err != nil {
+		t.Fatalf("invalid JSON %q: %v", output, err)
+	}
+	if err := schema.Validate(log); err != nil {
+		t.Errorf("the SARIF log does not match the SARIF 2.1.0 schema: %v", err)
+	}
+}
@@ -0,0 +1,66 @@
+package formatter
+
+import (
+	"bytes"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// sourceLine is a line of the source code of a failure.
+type sourceLine struct {
+	// Number is the number of the line, starting at 1.
+	Number int
+	// Before is the text of the line before the range of the failure.
+	Before string
+	// Failure is the text of the line in the range of the failure.
+	Failure string
+	// After is the text of the line after the range of the failure.
+	After string
+}
+
+// sourceExcerpt returns the lines of the failure, limited to maxLines, surrounded by context lines.
+// It returns nil if the content of the file of the failure, or its position, are unknown.
+func sourceExcerpt(failure lint.Failure, context, maxLines int) []sourceLine {
+	start, end := failure.Position.Start, failure.Position.End
+	if failure.FileContent == nil || start.Line < 1 {
+		return nil
+	}
+	if end.Line < start.Line {
+		// the end is unknown, the failure spans the end of its line
+		end.Line, end.Column = start.Line, 0
+	}
+
+	lines := bytes.Split(failure.FileContent, []byte("\n"))
+	if start.Line > len(lines) {
+		return nil
+	}
+	// the lines of the failure after lastFailure are truncated, only shown as context
+	lastFailure := min(end.Line, start.Line+maxLines-1)
+	first := max(start.Line-context, 1)
+	last := min(lastFailure+context, len(lines))
+
+	excerpt := make([]sourceLine, 0, last-first+1)
+	for number := first; number <= last; number++ {
+		text := string(bytes.TrimSuffix(lines[number-1], []byte("\r")))
+		if number < start.Line || number > lastFailure {
+			excerpt = append(excerpt, sourceLine{Number: number, Before: text})
+			continue
+		}
+
+		// columns are 1-based byte offsets, the end being exclusive
+		from, to := 1, len(text)+1
+		if number == start.Line {
+			from = min(max(start.Column, 1), len(text)+1)
+		}
+		if number == end.Line && end.Column > 0 {
+			to = min(max(end.Column, from), len(text)+1)
+		}
+		excerpt = append(excerpt, sourceLine{
+			Number:  number,
+			Before:  text[:from-1],
+			Failure: text[from-1 : to-1],
+			After:   text[to-1:],
+		})
+	}
+	return excerpt
+}
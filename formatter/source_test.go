@@ -0,0 +1,95 @@
+package formatter
+
+import (
+	"go/token"
+	"reflect"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestSourceExcerpt(t *testing.T) {
+	content := []byte("line 1\r\nline 2\nline 3\nline 4\nline 5\n")
+	tests := []struct {
+		name       string
+		start, end token.Position
+		context    int
+		maxLines   int
+		want       []sourceLine
+	}{
+		{
+			name:     "single line",
+			start:    token.Position{Line: 2, Column: 3},
+			end:      token.Position{Line: 2, Column: 5},
+			context:  1,
+			maxLines: 5,
+			want: []sourceLine{
+				{Number: 1, Before: "line 1"},
+				{Number: 2, Before: "li", Failure: "ne", After: " 2"},
+				{Number: 3, Before: "line 3"},
+			},
+		},
+		{
+			name:     "several lines",
+			start:    token.Position{Line: 2, Column: 6},
+			end:      token.Position{Line: 3, Column: 2},
+			maxLines: 5,
+			want: []sourceLine{
+				{Number: 2, Before: "line ", Failure: "2"},
+				{Number: 3, Failure: "l", After: "ine 3"},
+			},
+		},
+		{
+			name:     "limited lines",
+			start:    token.Position{Line: 1, Column: 1},
+			end:      token.Position{Line: 5, Column: 7},
+			maxLines: 2,
+			want: []sourceLine{
+				{Number: 1, Failure: "line 1"},
+				{Number: 2, Failure: "line 2"},
+			},
+		},
+		{
+			name:     "limited lines with context",
+			start:    token.Position{Line: 1, Column: 1},
+			end:      token.Position{Line: 5, Column: 7},
+			context:  1,
+			maxLines: 2,
+			want: []sourceLine{
+				{Number: 1, Failure: "line 1"},
+				{Number: 2, Failure: "line 2"},
+				{Number: 3, Before: "line 3"},
+			},
+		},
+		{
+			name:     "unknown end",
+			start:    token.Position{Line: 5, Column: 6},
+			context:  1,
+			maxLines: 5,
+			want: []sourceLine{
+				{Number: 4, Before: "line 4"},
+				{Number: 5, Before: "line ", Failure: "5"},
+				{Number: 6},
+			},
+		},
+		{
+			name:     "unknown position",
+			maxLines: 5,
+		},
+		{
+			name:     "out of the file",
+			start:    token.Position{Line: 10, Column: 1},
+			maxLines: 5,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			failure := lint.Failure{Position: lint.FailurePosition{Start: tt.start, End: tt.end}, FileContent: content}
+			got := sourceExcerpt(failure, tt.context, tt.maxLines)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
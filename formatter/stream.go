@@ -0,0 +1,49 @@
+package formatter
+
+import (
+	"io"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// format formats the failures with the formatter, and returns the report as a string.
+func format(f lint.StreamFormatter, failures <-chan lint.Failure, config lint.Config) (string, error) {
+	var sb strings.Builder
+	fw, err := f.Begin(&sb, config)
+	if err != nil {
+		return "", err
+	}
+
+	// the channel is read until it is closed, to not block the sender
+	var formatErr error
+	for failure := range failures {
+		if formatErr == nil {
+			formatErr = fw.Failure(failure)
+		}
+	}
+
+	if formatErr == nil {
+		formatErr = fw.End()
+	}
+	if formatErr != nil {
+		return "", formatErr
+	}
+
+	return sb.String(), nil
+}
+
+// lineWriter writes a line per failure.
+type lineWriter struct {
+	w    io.Writer
+	line func(lint.Failure) string
+}
+
+func (lw *lineWriter) Failure(failure lint.Failure) error {
+	_, err := io.WriteString(lw.w, lw.line(failure)+"\n")
+	return err
+}
+
+func (*lineWriter) End() error {
+	return nil
+}
@@ -2,6 +2,8 @@ package formatter
 
 import (
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/fatih/color"
 
@@ -32,35 +34,88 @@ func formatFailure(failure lint.Failure, severity lint.Severity) []string {
 }
 
 // Format formats the failures gotten from the lint.
-func (*Stylish) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
-	var result [][]string
-	totalErrors := 0
-	total := 0
-
-	for f := range failures {
-		total++
-		currentType := severity(config, f)
-		if currentType == lint.SeverityError {
-			totalErrors++
-		}
-		result = append(result, formatFailure(f, lint.Severity(currentType)))
+func (f *Stylish) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (f *Stylish) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	withCodeFrames, err := codeFrameEnabled(config.Formatters[f.Name()])
+	if err != nil {
+		return nil, err
+	}
+
+	return &stylishWriter{w: w, config: config, withCodeFrames: withCodeFrames}, nil
+}
+
+// stylishWriter writes the failures in tables at the end, as the tables are aligned on their widest cells.
+type stylishWriter struct {
+	w              io.Writer
+	config         lint.Config
+	withCodeFrames bool
+	result         [][]string
+	// frames are the code frames of the failures of result, if enabled
+	frames      []string
+	totalErrors int
+	total       int
+}
+
+func (fw *stylishWriter) Failure(f lint.Failure) error {
+	fw.total++
+	currentType := severity(fw.config, f)
+	if currentType == lint.SeverityError {
+		fw.totalErrors++
+	}
+	fw.result = append(fw.result, formatFailure(f, lint.Severity(currentType)))
+	if fw.withCodeFrames {
+		fw.frames = append(fw.frames, codeFrame(f, currentType, "    "))
 	}
+	return nil
+}
+
+// stylishFile holds the rows of the table of the failures of a file, and their code frames.
+type stylishFile struct {
+	rows   [][]string
+	frames []string
+}
 
-	fileReport := map[string][][]string{}
+func (fw *stylishWriter) End() error {
+	result, totalErrors, total := fw.result, fw.totalErrors, fw.total
 
-	for _, row := range result {
-		if _, ok := fileReport[row[0]]; !ok {
-			fileReport[row[0]] = [][]string{}
+	// the files are reported in the order of their first failure
+	fileReport := map[string]*stylishFile{}
+	var filenames []string
+	for i, row := range result {
+		file, ok := fileReport[row[0]]
+		if !ok {
+			file = &stylishFile{}
+			fileReport[row[0]] = file
+			filenames = append(filenames, row[0])
 		}
 
-		fileReport[row[0]] = append(fileReport[row[0]], []string{row[1], row[2], row[3]})
+		file.rows = append(file.rows, []string{row[1], row[2], row[3]})
+		if fw.withCodeFrames {
+			file.frames = append(file.frames, fw.frames[i])
+		}
 	}
 
 	output := ""
-	for filename, val := range fileReport {
+	for _, filename := range filenames {
 		c := color.New(color.Underline)
 		output += c.SprintfFunc()(filename + "\n")
-		output += table(val) + "\n"
+		file := fileReport[filename]
+		if !fw.withCodeFrames {
+			output += table(file.rows) + "\n"
+			continue
+		}
+		// the table has a line per row, each followed by the code frame of its failure
+		for i, line := range strings.SplitAfter(table(file.rows), "\n") {
+			output += line
+			if i < len(file.frames) {
+				output += file.frames[i]
+			}
+		}
+		output += "\n"
 	}
 
 	problemsLabel := "problems"
@@ -87,5 +142,6 @@ func (*Stylish) Format(failures <-chan lint.Failure, config lint.Config) (string
 		suffix, output = "", ""
 	}
 
-	return output + suffix, nil
+	_, err := io.WriteString(fw.w, output+suffix)
+	return err
 }
@@ -0,0 +1,89 @@
+package formatter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mgechev/revive/internal/ruledoc"
+	"github.com/mgechev/revive/lint"
+)
+
+// TeamCity is an implementation of the Formatter interface
+// which formats the failures as TeamCity service messages, reported as inspections of the build.
+// Each rule is registered as an inspection type before its first failure
+//
+//	##teamcity[inspectionType id='errorf' name='errorf' description='Should replace `errors.New(fmt.Sprintf())` with `fmt.Errorf()`' category='errors']
+//	##teamcity[inspection typeId='errorf' message='should replace errors.New(fmt.Sprintf(...)) with fmt.Errorf(...)' file='main.go' line='24' SEVERITY='WARNING']
+type TeamCity struct {
+	Metadata lint.FormatterMetadata
+}
+
+// Name returns the name of the formatter.
+func (*TeamCity) Name() string {
+	return "teamcity"
+}
+
+// Format formats the failures gotten from the lint.
+func (f *TeamCity) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (*TeamCity) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	return &teamCityWriter{w: w, config: config, inspectionTypes: map[string]bool{}}, nil
+}
+
+// teamCityWriter writes the service messages of the failures, registering the inspection types as they are met.
+type teamCityWriter struct {
+	w               io.Writer
+	config          lint.Config
+	inspectionTypes map[string]bool
+}
+
+func (fw *teamCityWriter) Failure(failure lint.Failure) error {
+	var sb strings.Builder
+	if !fw.inspectionTypes[failure.RuleName] {
+		fw.inspectionTypes[failure.RuleName] = true
+		description := failure.RuleName
+		if d, ok := ruledoc.Of(failure.RuleName); ok {
+			description = d.Short
+		}
+		category := string(failure.Category)
+		if category == "" {
+			category = "revive"
+		}
+		fmt.Fprintf(&sb, "##teamcity[inspectionType id='%s' name='%s' description='%s' category='%s']\n",
+			teamCityValue(failure.RuleName), teamCityValue(failure.RuleName), teamCityValue(description), teamCityValue(category))
+	}
+
+	teamCitySeverity := "WARNING"
+	if severity(fw.config, failure) == lint.SeverityError {
+		teamCitySeverity = "ERROR"
+	}
+	fmt.Fprintf(&sb, "##teamcity[inspection typeId='%s' message='%s' file='%s' line='%d' SEVERITY='%s']\n",
+		teamCityValue(failure.RuleName), teamCityValue(failure.Failure), teamCityValue(relativePath(failure.Filename())),
+		max(failure.Position.Start.Line, 0), teamCitySeverity)
+
+	_, err := io.WriteString(fw.w, sb.String())
+	return err
+}
+
+func (*teamCityWriter) End() error {
+	return nil
+}
+
+// teamCityValue escapes the value of an attribute of a service message.
+func teamCityValue(s string) string {
+	return strings.NewReplacer(
+		"|", "||",
+		"'", "|'",
+		"\n", "|n",
+		"\r", "|r",
+		"[", "|[",
+		"]", "|]",
+		"\u0085", "|x",
+		"\u2028", "|l",
+		"\u2029", "|p",
+	).Replace(s)
+}
@@ -0,0 +1,188 @@
+package formatter
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// Template is an implementation of the Formatter interface
+// which formats the failures with a user-defined text/template.
+//
+// The template is set in the [formatter.template] section of the configuration,
+// either inline or as the path of a file:
+//
+//	[formatter.template]
+//	file = "revive.tmpl"
+//
+// The template is executed once with all the failures, see templateData for the data it gets,
+// and templateFuncs for the functions it can call.
+type Template struct {
+	Metadata lint.FormatterMetadata
+}
+
+// Name returns the name of the formatter.
+func (*Template) Name() string {
+	return "template"
+}
+
+// WritesOwners tells that the formatter writes the owners of the failures.
+func (*Template) WritesOwners() bool {
+	return true
+}
+
+// Options of the [formatter.template] section of the configuration.
+const (
+	// TemplateFileOption is the path of the file of the template.
+	TemplateFileOption = "file"
+	// TemplateTextOption is the text of the template.
+	TemplateTextOption = "template"
+	// TemplateNameOption is the name of the template given by its text, in the errors of the template.
+	TemplateNameOption = "name"
+)
+
+// templateData is the data the template is executed with.
+type templateData struct {
+	// Failures are the failures, in the sort order of the run: by position unless set otherwise.
+	Failures []lint.Failure
+	// Errors are the messages of the errors that prevented linting.
+	Errors []string
+	// Revive is the metadata of the run.
+	Revive runInfo
+}
+
+// templateGroup is a group of failures sharing a file or a rule.
+type templateGroup struct {
+	Name     string
+	Failures []lint.Failure
+}
+
+// Format formats the failures gotten from the lint.
+func (f *Template) Format(failures <-chan lint.Failure, config lint.Config) (string, error) {
+	return format(f, failures, config)
+}
+
+// Begin starts a report written to w.
+func (f *Template) Begin(w io.Writer, config lint.Config) (lint.FailureWriter, error) {
+	name, text, err := templateText(config.Formatters[f.Name()])
+	if err != nil {
+		return nil, err
+	}
+
+	t, err := template.New(name).Funcs(templateFuncs(config)).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+
+	return &templateWriter{w: w, config: config, template: t}, nil
+}
+
+// templateText returns the name and the text of the template set in the options.
+func templateText(options lint.FormatterConfig) (name, text string, err error) {
+	file, hasFile := options[TemplateFileOption]
+	inline, hasInline := options[TemplateTextOption]
+	switch {
+	case hasFile && hasInline:
+		return "", "", fmt.Errorf("the %q and %q options of the template formatter are mutually exclusive", TemplateFileOption, TemplateTextOption)
+	case hasFile:
+		path, ok := file.(string)
+		if !ok {
+			return "", "", fmt.Errorf("invalid %s option: expected a string, got %T", TemplateFileOption, file)
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return "", "", fmt.Errorf("cannot read the template: %w", err)
+		}
+		return filepath.Base(path), string(content), nil
+	case hasInline:
+		text, ok := inline.(string)
+		if !ok {
+			return "", "", fmt.Errorf("invalid %s option: expected a string, got %T", TemplateTextOption, inline)
+		}
+		name, ok := options[TemplateNameOption].(string)
+		if !ok {
+			name = "revive"
+		}
+		return name, text, nil
+	default:
+		return "", "", errors.New("the template formatter needs a template: use the -template flag, or set it in the [formatter.template] section of the configuration")
+	}
+}
+
+// templateFuncs returns the functions available to the templates:
+//
+//   - relativePath returns the slash-separated path of a file, relative to the working directory;
+//   - severity returns the severity of a failure;
+//   - json returns a value encoded in JSON, like a string quoted and escaped;
+//   - xml returns a string escaped to be the text or an attribute of an XML element;
+//   - groupByFile and groupByRule group failures by file or by rule, sorted by name.
+func templateFuncs(config lint.Config) template.FuncMap {
+	return template.FuncMap{
+		"relativePath": relativePath,
+		"severity": func(failure lint.Failure) lint.Severity {
+			return severity(config, failure)
+		},
+		"json": func(value any) (string, error) {
+			encoded, err := json.Marshal(value)
+			return string(encoded), err
+		},
+		"xml": func(text string) (string, error) {
+			var sb strings.Builder
+			err := xml.EscapeText(&sb, []byte(text))
+			return sb.String(), err
+		},
+		"groupByFile": func(failures []lint.Failure) []templateGroup {
+			return templateGroups(failures, func(failure lint.Failure) string { return relativePath(failure.Filename()) })
+		},
+		"groupByRule": func(failures []lint.Failure) []templateGroup {
+			return templateGroups(failures, func(failure lint.Failure) string { return failure.RuleName })
+		},
+	}
+}
+
+// templateGroups groups the failures by key, keeping their order in each group.
+func templateGroups(failures []lint.Failure, key func(lint.Failure) string) []templateGroup {
+	byKey := map[string][]lint.Failure{}
+	for _, failure := range failures {
+		byKey[key(failure)] = append(byKey[key(failure)], failure)
+	}
+
+	groups := make([]templateGroup, 0, len(byKey))
+	for _, name := range slices.Sorted(maps.Keys(byKey)) {
+		groups = append(groups, templateGroup{Name: name, Failures: byKey[name]})
+	}
+	return groups
+}
+
+// templateWriter executes the template at the end, with all the failures.
+type templateWriter struct {
+	w        io.Writer
+	config   lint.Config
+	template *template.Template
+	data     templateData
+}
+
+func (fw *templateWriter) Failure(failure lint.Failure) error {
+	if failure.IsInternal() {
+		fw.data.Errors = append(fw.data.Errors, failure.Failure)
+		return nil
+	}
+
+	fw.data.Failures = append(fw.data.Failures, failure)
+	return nil
+}
+
+func (fw *templateWriter) End() error {
+	fw.data.Revive = newRunInfo(fw.config)
+	return fw.template.Execute(fw.w, fw.data)
+}
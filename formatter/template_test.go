@@ -0,0 +1,106 @@
+package formatter
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		want     string
+	}{
+		{
+			name:     "failures",
+			template: `{{range .Failures}}{{relativePath .Filename}}:{{.Position.Start.Line}} {{severity .}} {{.RuleName}}: {{.Failure}}{{"\n"}}{{end}}`,
+			want:     "pkg/test.go:2 error rule: a <failure>\npkg/other.go:2 warning other-rule: another \"failure\"\n",
+		},
+		{
+			name:     "escaping",
+			template: `{{range .Failures}}<issue message="{{xml .Failure}}" json={{json .Failure}}/>{{"\n"}}{{end}}`,
+			want:     "<issue message=\"a &lt;failure&gt;\" json=\"a \\u003cfailure\\u003e\"/>\n<issue message=\"another &#34;failure&#34;\" json=\"another \\\"failure\\\"\"/>\n",
+		},
+		{
+			name:     "group by file",
+			template: `{{range groupByFile .Failures}}{{.Name}}: {{len .Failures}}{{"\n"}}{{end}}`,
+			want:     "pkg/other.go: 1\npkg/test.go: 1\n",
+		},
+		{
+			name:     "group by rule",
+			template: `{{range groupByRule .Failures}}{{.Name}}: {{len .Failures}}{{"\n"}}{{end}}`,
+			want:     "other-rule: 1\nrule: 1\n",
+		},
+		{
+			name:     "errors",
+			template: `{{range .Errors}}{{.}}{{end}}`,
+			want:     "cannot lint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := lint.Config{
+				Rules:      lint.RulesConfig{"rule": {Severity: lint.SeverityError}},
+				Formatters: lint.FormattersConfig{"template": {TemplateTextOption: tt.template}},
+			}
+			other := testFailure(`another "failure"`, "")
+			other.RuleName = "other-rule"
+			other.Position.Start.Filename = "pkg/other.go"
+			failures := make(chan lint.Failure, 3)
+			failures <- testFailure("a <failure>", "")
+			failures <- other
+			failures <- lint.NewInternalFailure("cannot lint")
+			close(failures)
+
+			got, err := (&Template{}).Format(failures, config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplate_file(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.tmpl")
+	if err := os.WriteFile(path, []byte("{{len .Failures}} failures"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := lint.Config{Formatters: lint.FormattersConfig{"template": {TemplateFileOption: path}}}
+	failures := make(chan lint.Failure, 1)
+	failures <- testFailure("test failure", "")
+	close(failures)
+
+	got, err := (&Template{}).Format(failures, config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "1 failures"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestTemplate_invalidOptions(t *testing.T) {
+	for _, tt := range []struct {
+		options lint.FormatterConfig
+		want    string
+	}{
+		{options: nil, want: "needs a template"},
+		{options: lint.FormatterConfig{TemplateFileOption: "a.tmpl", TemplateTextOption: "text"}, want: "mutually exclusive"},
+		{options: lint.FormatterConfig{TemplateFileOption: filepath.Join(t.TempDir(), "missing.tmpl")}, want: "cannot read the template"},
+		{options: lint.FormatterConfig{TemplateTextOption: "{{.Failures"}, want: "invalid template"},
+	} {
+		config := lint.Config{Formatters: lint.FormattersConfig{"template": tt.options}}
+		_, err := (&Template{}).Begin(&strings.Builder{}, config)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("got error %v for the options %v, want an error containing %q", err, tt.options, tt.want)
+		}
+	}
+}
@@ -8,7 +8,7 @@ require (
 	github.com/fatih/color v1.18.0
 	github.com/fatih/structtag v1.2.0
 	github.com/hashicorp/go-version v1.7.0
-	github.com/mgechev/dots v1.0.0
+	github.com/santhosh-tekuri/jsonschema/v6 v6.0.2
 	github.com/spf13/afero v1.14.0
 	golang.org/x/mod v0.27.0
 	golang.org/x/sync v0.16.0
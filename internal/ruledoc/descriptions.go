@@ -0,0 +1,476 @@
+// Code generated by generate.go; DO NOT EDIT.
+
+package ruledoc
+
+var descriptions = map[string]Description{
+	"add-constant": {
+		Short:        "Suggests using constant for magic numbers and string literals",
+		Full:         "Suggests using constant for magic numbers and string literals.",
+		FullMarkdown: "Suggests using constant for [magic numbers](https://en.wikipedia.org/wiki/Magic_number_(programming)#Unnamed_numerical_constants)\nand string literals.",
+	},
+	"argument-limit": {
+		Short:        "Specifies the maximum number of arguments a function can receive",
+		Full:         "Warns when a function receives more parameters than the maximum set by the rule's configuration. Enforcing a maximum number of parameters helps to keep the code readable and maintainable.",
+		FullMarkdown: "Warns when a function receives more parameters than the maximum set by the rule's configuration.\nEnforcing a maximum number of parameters helps to keep the code readable and maintainable.",
+	},
+	"atomic": {
+		Short:        "Check for common mistaken usages of the `sync/atomic` package",
+		Full:         "Check for commonly mistaken usages of the `sync/atomic` package",
+		FullMarkdown: "Check for commonly mistaken usages of the `sync/atomic` package",
+	},
+	"banned-characters": {
+		Short:        "Checks banned characters in identifiers",
+		Full:         "Checks given banned characters in identifiers(func, var, const). Comments are not checked.",
+		FullMarkdown: "Checks given banned characters in identifiers(func, var, const). Comments are not checked.",
+	},
+	"bare-return": {
+		Short:        "Warns on bare returns",
+		Full:         "Warns on bare (a.k.a. naked) returns",
+		FullMarkdown: "Warns on bare (a.k.a. naked) returns",
+	},
+	"blank-imports": {
+		Short:        "Disallows blank imports",
+		Full:         "Blank import should be only in a main or test package, or have a comment justifying it.",
+		FullMarkdown: "Blank import should be only in a main or test package, or have a comment justifying it.",
+	},
+	"bool-literal-in-expr": {
+		Short:        "Suggests removing Boolean literals from logic expressions",
+		Full:         "Using Boolean literals (`true`, `false`) in logic expressions may make the code less readable. This rule suggests removing Boolean literals from logic expressions.",
+		FullMarkdown: "Using Boolean literals (`true`, `false`) in logic expressions may make the code less readable.\nThis rule suggests removing Boolean literals from logic expressions.",
+	},
+	"call-to-gc": {
+		Short:        "Warns on explicit call to the garbage collector",
+		Full:         "Explicitly invoking the garbage collector is, except for specific uses in benchmarking, very dubious.",
+		FullMarkdown: "Explicitly invoking the garbage collector is, except for specific uses in benchmarking, very dubious.",
+	},
+	"cognitive-complexity": {
+		Short:        "Sets restriction for maximum Cognitive complexity.",
+		Full:         "Cognitive complexity is a measure of how hard code is to understand. While cyclomatic complexity is good to measure \"testability\" of the code, cognitive complexity aims to provide a more precise measure of the difficulty of understanding the code. Enforcing a maximum complexity per function helps to keep code readable and maintainable.",
+		FullMarkdown: "[Cognitive complexity](https://www.sonarsource.com/docs/CognitiveComplexity.pdf) is a measure of how hard code is to understand.\nWhile cyclomatic complexity is good to measure \"testability\" of the code,\ncognitive complexity aims to provide a more precise measure of the difficulty of understanding the code.\nEnforcing a maximum complexity per function helps to keep code readable and maintainable.",
+	},
+	"comment-spacings": {
+		Short:        "Warns on malformed comments",
+		Full:         "Spots comments of the form:",
+		FullMarkdown: "Spots comments of the form:",
+	},
+	"comments-density": {
+		Short:        "Enforces a minimum comment / code relation",
+		Full:         "Spots files not respecting a minimum value for the _comments lines density_ metric = _comment lines / (lines of code + comment lines) * 100_",
+		FullMarkdown: "Spots files not respecting a minimum value for the [_comments lines density_](https://docs.sonarsource.com/sonarqube/latest/user-guide/metric-definitions/)\nmetric = _comment lines / (lines of code + comment lines) * 100_",
+	},
+	"confusing-naming": {
+		Short:        "Warns on methods with names that differ only by capitalization",
+		Full:         "Methods or fields of `struct` that have names different only by capitalization could be confusing.",
+		FullMarkdown: "Methods or fields of `struct` that have names different only by capitalization could be confusing.",
+	},
+	"confusing-results": {
+		Short:        "Suggests to name potentially confusing function results",
+		Full:         "Function or methods that return multiple, no named, values of the same type could induce error.",
+		FullMarkdown: "Function or methods that return multiple, no named, values of the same type could induce error.",
+	},
+	"constant-logical-expr": {
+		Short:        "Warns on constant logical expressions",
+		Full:         "The rule spots logical expressions that evaluate always to the same value.",
+		FullMarkdown: "The rule spots logical expressions that evaluate always to the same value.",
+	},
+	"context-as-argument": {
+		Short:        "`context.Context` should be the first argument of a function.",
+		Full:         "By convention, `context.Context` should be the first parameter of a function. This rule spots function declarations that do not follow the convention.",
+		FullMarkdown: "By [convention](https://go.dev/wiki/CodeReviewComments#contexts), `context.Context` should be the first parameter of a function.\nThis rule spots function declarations that do not follow the convention.",
+	},
+	"context-keys-type": {
+		Short:        "Disallows the usage of basic types in `context.WithValue`.",
+		Full:         "Basic types should not be used as a key in `context.WithValue`.",
+		FullMarkdown: "Basic types should not be used as a key in `context.WithValue`.",
+	},
+	"cyclomatic": {
+		Short:        "Sets restriction for maximum Cyclomatic complexity.",
+		Full:         "Cyclomatic complexity is a measure of code complexity. Enforcing a maximum complexity per function helps to keep code readable and maintainable.",
+		FullMarkdown: "[Cyclomatic complexity](https://en.wikipedia.org/wiki/Cyclomatic_complexity) is a measure of code complexity.\nEnforcing a maximum complexity per function helps to keep code readable and maintainable.",
+	},
+	"datarace": {
+		Short:        "Spots potential dataraces",
+		Full:         "This rule spots potential dataraces caused by goroutines capturing (by-reference) particular identifiers of the function from which goroutines are created. The rule is able to spot two of such cases: go-routines capturing named return values, and capturing `for-range` values.",
+		FullMarkdown: "This rule spots potential dataraces caused by goroutines capturing (by-reference) particular identifiers of the function from\nwhich goroutines are created.\nThe rule is able to spot two of such cases: go-routines capturing named return values, and capturing `for-range` values.",
+	},
+	"deep-exit": {
+		Short:        "Looks for program exits in funcs other than `main()` or `init()`",
+		Full:         "Packages exposing functions that can stop program execution by exiting are hard to reuse. This rule looks for program exits in functions other than `main()` or `init()`.",
+		FullMarkdown: "Packages exposing functions that can stop program execution by exiting are hard to reuse.\nThis rule looks for program exits in functions other than `main()` or `init()`.",
+	},
+	"defer": {
+		Short:        "Warns on some [defer gotchas](https://blog.learngoprogramming.com/5-gotchas-of-defer-in-go-golang-part-iii-36a1ab3d6ef1)",
+		Full:         "This rule warns on some common mistakes when using `defer` statement. It currently alerts on the following situations:",
+		FullMarkdown: "This rule warns on some common mistakes when using `defer` statement. It currently alerts on the following situations:",
+	},
+	"dot-imports": {
+		Short:        "Forbids `.` imports.",
+		Full:         "Importing with `.` makes the programs much harder to understand because it is unclear whether names belong to the current package or to an imported package.",
+		FullMarkdown: "Importing with `.` makes the programs much harder to understand because it is unclear whether names belong to the current package or\nto an imported package.",
+	},
+	"duplicated-imports": {
+		Short:        "Looks for packages that are imported two or more times",
+		Full:         "It is possible to unintentionally import the same package twice. This rule looks for packages that are imported two or more times.",
+		FullMarkdown: "It is possible to unintentionally import the same package twice. This rule looks for packages that are imported two or more times.",
+	},
+	"early-return": {
+		Short:        "Spots if-then-else statements where the predicate may be inverted to reduce nesting",
+		Full:         "In Go it is idiomatic to minimize nesting statements, a typical example is to avoid if-then-else constructions. This rule spots constructions like",
+		FullMarkdown: "In Go it is idiomatic to minimize nesting statements, a typical example is to avoid if-then-else constructions.\nThis rule spots constructions like",
+	},
+	"empty-block": {
+		Short:        "Warns on empty code blocks",
+		Full:         "Empty blocks make code less readable and could be a symptom of a bug or unfinished refactoring.",
+		FullMarkdown: "Empty blocks make code less readable and could be a symptom of a bug or unfinished refactoring.",
+	},
+	"empty-lines": {
+		Short:        "Warns when there are heading or trailing newlines in a block",
+		Full:         "Sometimes `gofmt` is not enough to enforce a common formatting of a code-base; this rule warns when there are heading or trailing newlines in code blocks.",
+		FullMarkdown: "Sometimes `gofmt` is not enough to enforce a common formatting of a code-base;\nthis rule warns when there are heading or trailing newlines in code blocks.",
+	},
+	"enforce-else": {
+		Short:        "Enforces `else` branch at the end of `if...else if` chains.",
+		Full:         "This rule warns if an `if` statement followed by one or more `else if` statements does not have a final `else` statement.",
+		FullMarkdown: "This rule warns if an `if` statement followed by one or more `else if` statements does not have a final `else` statement.",
+	},
+	"enforce-map-style": {
+		Short:        "Enforces consistent usage of `make(map[type]type)` or `map[type]type{}` for map initialization. Does not affect `make(map[type]type, size)` constructions.",
+		Full:         "This rule enforces consistent usage of `make(map[type]type)` or `map[type]type{}` for map initialization. It does not affect `make(map[type]type, size)` constructions as well as `map[type]type{k1: v1}`.",
+		FullMarkdown: "This rule enforces consistent usage of `make(map[type]type)` or `map[type]type{}` for map initialization.\nIt does not affect `make(map[type]type, size)` constructions as well as `map[type]type{k1: v1}`.",
+	},
+	"enforce-repeated-arg-type-style": {
+		Short:        "Enforces consistent style for repeated argument and/or return value types.",
+		Full:         "This rule is designed to maintain consistency in the declaration of repeated argument and return value types in Go functions. It supports three styles: 'any', 'short', and 'full'. The 'any' style is lenient and allows any form of type declaration. The 'short' style encourages omitting repeated types for conciseness, whereas the 'full' style mandates explicitly stating the type for each argument and return value, even if they are repeated, promoting clarity.",
+		FullMarkdown: "This rule is designed to maintain consistency in the declaration of repeated argument and return value types in Go functions.\nIt supports three styles: 'any', 'short', and 'full'.\nThe 'any' style is lenient and allows any form of type declaration.\nThe 'short' style encourages omitting repeated types for conciseness,\nwhereas the 'full' style mandates explicitly stating the type for each argument\nand return value, even if they are repeated, promoting clarity.",
+	},
+	"enforce-slice-style": {
+		Short:        "Enforces consistent usage of `make([]type, 0)` or `[]type{}` for slice initialization. Does not affect `make(map[type]type, non_zero_len, or_non_zero_cap)` constructions.",
+		Full:         "This rule enforces consistent usage of `make([]type, 0)`, `[]type{}`, or `var []type` for slice initialization. It does not affect `make([]type, non_zero_len, or_non_zero_cap)` constructions as well as `[]type{v1}`. Nil slices are always permitted.",
+		FullMarkdown: "This rule enforces consistent usage of `make([]type, 0)`, `[]type{}`, or `var []type` for slice initialization.\nIt does not affect `make([]type, non_zero_len, or_non_zero_cap)` constructions as well as `[]type{v1}`.\nNil slices are always permitted.",
+	},
+	"enforce-switch-style": {
+		Short:        "Enforces consistent usage of `default` on `switch` statements.",
+		Full:         "This rule enforces consistent usage of `default` on `switch` statements. It can check for `default` case clause occurrence and/or position in the list of case clauses.",
+		FullMarkdown: "This rule enforces consistent usage of `default` on `switch` statements.\nIt can check for `default` case clause occurrence and/or position in the list of case clauses.",
+	},
+	"error-naming": {
+		Short:        "Naming of error variables.",
+		Full:         "By convention, for the sake of readability, variables of type `error` must be named with the prefix `err`.",
+		FullMarkdown: "By convention, for the sake of readability, variables of type `error` must be named with the prefix `err`.",
+	},
+	"error-return": {
+		Short:        "The error return parameter should be last.",
+		Full:         "By convention, for the sake of readability, the errors should be last in the list of returned values by a function.",
+		FullMarkdown: "By convention, for the sake of readability, the errors should be last in the list of returned values by a function.",
+	},
+	"error-strings": {
+		Short:        "Conventions around error strings.",
+		Full:         "By convention, for better readability, error messages should not be capitalized or end with punctuation or a newline. By default, the rule analyzes functions for creating errors from `fmt`, `errors`, and `github.com/pkg/errors`. Optionally, the rule can be configured to analyze user functions that create errors.",
+		FullMarkdown: "By convention, for better readability, error messages should not be capitalized or end with punctuation or a newline.\nBy default, the rule analyzes functions for creating errors from `fmt`, `errors`, and `github.com/pkg/errors`.\nOptionally, the rule can be configured to analyze user functions that create errors.",
+	},
+	"errorf": {
+		Short:        "Should replace `errors.New(fmt.Sprintf())` with `fmt.Errorf()`",
+		Full:         "It is possible to get a simpler program by replacing `errors.New(fmt.Sprintf())` with `fmt.Errorf()`. This rule spots that kind of simplification opportunities.",
+		FullMarkdown: "It is possible to get a simpler program by replacing `errors.New(fmt.Sprintf())` with `fmt.Errorf()`.\nThis rule spots that kind of simplification opportunities.",
+	},
+	"exported": {
+		Short:        "Naming and commenting conventions on exported symbols.",
+		Full:         "Exported function and methods should have comments. This warns on undocumented exported functions and methods.",
+		FullMarkdown: "Exported function and methods should have comments. This warns on undocumented exported functions and methods.",
+	},
+	"file-header": {
+		Short:        "Header which each file should have.",
+		Full:         "This rule helps to enforce a common header for all source files in a project by spotting those files that do not have the specified header.",
+		FullMarkdown: "This rule helps to enforce a common header for all source files in a project by spotting those files that do not have the specified header.",
+	},
+	"file-length-limit": {
+		Short:        "Enforces a maximum number of lines per file",
+		Full:         "This rule enforces a maximum number of lines per file, in order to aid in maintainability and reduce complexity.",
+		FullMarkdown: "This rule enforces a maximum number of lines per file, in order to aid in maintainability and reduce complexity.",
+	},
+	"filename-format": {
+		Short:        "Enforces the formatting of filenames",
+		Full:         "enforces conventions on source file names. By default, the rule enforces filenames of the form `^[_A-Za-z0-9][_A-Za-z0-9-]*\\.go$`. Optionally, the rule can be configured to enforce other forms.",
+		FullMarkdown: "enforces conventions on source file names. By default, the rule enforces filenames of the form `^[_A-Za-z0-9][_A-Za-z0-9-]*\\.go$`.\nOptionally, the rule can be configured to enforce other forms.",
+	},
+	"flag-parameter": {
+		Short:        "Warns on boolean parameters that create a control coupling",
+		Full:         "If a function controls the flow of another by passing it information on what to do, both functions are said to be control-coupled. Coupling among functions must be minimized for better maintainability of the code. This rule warns on boolean parameters that create a control coupling.",
+		FullMarkdown: "If a function controls the flow of another by passing it information on what to do, both functions are said to be [control-coupled](https://en.wikipedia.org/wiki/Coupling_(computer_programming)#Procedural_programming).\nCoupling among functions must be minimized for better maintainability of the code.\nThis rule warns on boolean parameters that create a control coupling.",
+	},
+	"function-length": {
+		Short:        "Warns on functions exceeding the statements or lines max",
+		Full:         "Functions too long (with many statements and/or lines) can be hard to understand.",
+		FullMarkdown: "Functions too long (with many statements and/or lines) can be hard to understand.",
+	},
+	"function-result-limit": {
+		Short:        "Specifies the maximum number of results a function can return",
+		Full:         "Functions returning too many results can be hard to understand/use.",
+		FullMarkdown: "Functions returning too many results can be hard to understand/use.",
+	},
+	"get-return": {
+		Short:        "Warns on getters that do not yield any result",
+		Full:         "Typically, functions with names prefixed with _Get_ are supposed to return a value.",
+		FullMarkdown: "Typically, functions with names prefixed with _Get_ are supposed to return a value.",
+	},
+	"identical-branches": {
+		Short:        "Spots if-then-else statements with identical `then` and `else` branches",
+		Full:         "An `if-then-else` conditional with identical implementations in both branches is an error.",
+		FullMarkdown: "An `if-then-else` conditional with identical implementations in both branches is an error.",
+	},
+	"identical-ifelseif-branches": {
+		Short:        "Spots `if ... else if` chains with identical branches.",
+		Full:         "an `if ... else if` chain with identical branches makes maintenance harder and might be a source of bugs. Duplicated branches should be consolidated in one.",
+		FullMarkdown: "an `if ... else if` chain with identical branches makes maintenance harder\nand might be a source of bugs. Duplicated branches should be consolidated in one.",
+	},
+	"identical-ifelseif-conditions": {
+		Short:        "Spots identical conditions in  `if ... else if` chains.",
+		Full:         "an `if ... else if` chain  with identical conditions can lead to unreachable code and is a potential source of bugs while making the code harder to read and maintain.",
+		FullMarkdown: "an `if ... else if` chain  with identical conditions can lead to\nunreachable code and is a potential source of bugs while making the code harder to read and maintain.",
+	},
+	"identical-switch-branches": {
+		Short:        "Spots `switch` with identical branches.",
+		Full:         "a `switch` with identical branches makes maintenance harder and might be a source of bugs. Duplicated branches should be consolidated in one case clause.",
+		FullMarkdown: "a `switch` with identical branches makes maintenance harder\nand might be a source of bugs. Duplicated branches should be consolidated\nin one case clause.",
+	},
+	"identical-switch-conditions": {
+		Short:        "Spots identical conditions in case clauses of `switch` statements.",
+		Full:         "a `switch` statement with cases with the same condition can lead to unreachable code and is a potential source of bugs while making the code harder to read and maintain.",
+		FullMarkdown: "a `switch` statement with cases with the same condition can lead to\nunreachable code and is a potential source of bugs while making the code harder to read and maintain.",
+	},
+	"if-return": {
+		Short:        "Redundant if when returning an error.",
+		Full:         "Checking if an error is _nil_ to just after return the error or nil is redundant.",
+		FullMarkdown: "Checking if an error is _nil_ to just after return the error or nil is redundant.",
+	},
+	"import-alias-naming": {
+		Short:        "Conventions around the naming of import aliases.",
+		Full:         "Aligns with Go's naming conventions, as outlined in the official blog post. It enforces clear and lowercase import alias names, echoing the principles of good package naming. Users can follow these guidelines by default or define a custom regex rule. Importantly, aliases with underscores (\"_\") are always allowed.",
+		FullMarkdown: "Aligns with Go's naming conventions, as outlined in the official\n[blog post](https://go.dev/blog/package-names). It enforces clear and lowercase import alias names, echoing\nthe principles of good package naming. Users can follow these guidelines by default or define a custom regex rule.\nImportantly, aliases with underscores (\"_\") are always allowed.",
+	},
+	"import-shadowing": {
+		Short:        "Spots identifiers that shadow an import",
+		Full:         "In Go it is possible to declare identifiers (packages, structs, interfaces, parameters, receivers, variables, constants...) that conflict with the name of an imported package. This rule spots identifiers that shadow an import.",
+		FullMarkdown: "In Go it is possible to declare identifiers (packages, structs,\ninterfaces, parameters, receivers, variables, constants...) that conflict with the\nname of an imported package. This rule spots identifiers that shadow an import.",
+	},
+	"imports-blocklist": {
+		Short:        "Disallows importing the specified packages",
+		Full:         "Warns when importing block-listed packages.",
+		FullMarkdown: "Warns when importing block-listed packages.",
+	},
+	"increment-decrement": {
+		Short:        "Use `i++` and `i--` instead of `i += 1` and `i -= 1`.",
+		Full:         "By convention, for better readability, incrementing an integer variable by 1 is recommended to be done using the `++` operator. This rule spots expressions like `i += 1` and `i -= 1` and proposes to change them into `i++` and `i--`.",
+		FullMarkdown: "By convention, for better readability, incrementing an integer variable by 1 is recommended to be done using the `++` operator.\nThis rule spots expressions like `i += 1` and `i -= 1` and proposes to change them into `i++` and `i--`.",
+	},
+	"indent-error-flow": {
+		Short:        "Prevents redundant else statements.",
+		Full:         "To improve the readability of code, it is recommended to reduce the indentation as much as possible. This rule highlights redundant _else-blocks_ that can be eliminated from the code.",
+		FullMarkdown: "To improve the readability of code, it is recommended to reduce the indentation as much as possible.\nThis rule highlights redundant _else-blocks_ that can be eliminated from the code.",
+	},
+	"line-length-limit": {
+		Short:        "Specifies the maximum number of characters in a line",
+		Full:         "Warns in the presence of code lines longer than a configured maximum.",
+		FullMarkdown: "Warns in the presence of code lines longer than a configured maximum.",
+	},
+	"max-control-nesting": {
+		Short:        "Sets restriction for maximum nesting of control structures.",
+		Full:         "Warns if nesting level of control structures (`if-then-else`, `for`, `switch`) exceeds a given maximum.",
+		FullMarkdown: "Warns if nesting level of control structures (`if-then-else`, `for`, `switch`) exceeds a given maximum.",
+	},
+	"max-public-structs": {
+		Short:        "The maximum number of public structs in a file.",
+		Full:         "Packages declaring too many public structs can be hard to understand/use, and could be a symptom of bad design.",
+		FullMarkdown: "Packages declaring too many public structs can be hard to understand/use,\nand could be a symptom of bad design.",
+	},
+	"modifies-parameter": {
+		Short:        "Warns on assignments to function parameters",
+		Full:         "A function that modifies its parameters can be hard to understand. It can also be misleading if the arguments are passed by value by the caller. This rule warns when a function modifies one or more of its parameters or when parameters are passed to functions that modify them (e.g. `slices.Delete`).",
+		FullMarkdown: "A function that modifies its parameters can be hard to understand.\nIt can also be misleading if the arguments are passed by value by the caller.\nThis rule warns when a function modifies one or more of its parameters or when\nparameters are passed to functions that modify them (e.g. `slices.Delete`).",
+	},
+	"modifies-value-receiver": {
+		Short:        "Warns on assignments to value-passed method receivers",
+		Full:         "A method that modifies its receiver value can have undesired behavior. The modification can be also the root of a bug because the actual value receiver could be a copy of that used at the calling site. This rule warns when a method modifies its receiver.",
+		FullMarkdown: "A method that modifies its receiver value can have undesired behavior.\nThe modification can be also the root of a bug because the actual value receiver could be a copy of that used at the calling site.\nThis rule warns when a method modifies its receiver.",
+	},
+	"nested-structs": {
+		Short:        "Warns on structs within structs",
+		Full:         "Packages declaring structs that contain other inline struct definitions can be hard to understand/read for other developers.",
+		FullMarkdown: "Packages declaring structs that contain other inline struct definitions can be hard to understand/read for other developers.",
+	},
+	"optimize-operands-order": {
+		Short:        "Checks inefficient conditional expressions",
+		Full:         "Conditional expressions can be written to take advantage of short circuit evaluation and speed up its average evaluation time by forcing the evaluation of less time-consuming terms before more costly ones. This rule spots logical expressions where the order of evaluation of terms seems non optimal. Please notice that confidence of this rule is low and is up to the user to decide if the suggested rewrite of the expression keeps the semantics of the original one.",
+		FullMarkdown: "Conditional expressions can be written to take advantage of short circuit evaluation and speed up its average evaluation time\nby forcing the evaluation of less time-consuming terms before more costly ones.\nThis rule spots logical expressions where the order of evaluation of terms seems non optimal.\nPlease notice that confidence of this rule is low and is up to the user to decide if the suggested rewrite of the expression\nkeeps the semantics of the original one.",
+	},
+	"package-comments": {
+		Short:        "Package commenting conventions.",
+		Full:         "Packages should have comments. This rule warns on undocumented packages and when packages comments are detached to the `package` keyword.",
+		FullMarkdown: "Packages should have comments. This rule warns on undocumented packages and when packages comments are detached to the `package` keyword.",
+	},
+	"range": {
+		Short:        "Prevents redundant variables when iterating over a collection.",
+		Full:         "This rule suggests a shorter way of writing ranges that do not use the second value.",
+		FullMarkdown: "This rule suggests a shorter way of writing ranges that do not use the second value.",
+	},
+	"range-val-address": {
+		Short:        "Warns if address of range value is used dangerously",
+		Full:         "Range variables in a loop are reused at each iteration. This rule warns when assigning the address of the variable, passing the address to append() or using it in a map.",
+		FullMarkdown: "Range variables in a loop are reused at each iteration.\nThis rule warns when assigning the address of the variable, passing the address to append() or using it in a map.",
+	},
+	"range-val-in-closure": {
+		Short:        "Warns if range value is used in a closure dispatched as goroutine",
+		Full:         "Range variables in a loop are reused at each iteration; therefore a goroutine created in a loop will point to the range variable with from the upper scope. This way, the goroutine could use the variable with an undesired value. This rule warns when a range value (or index) is used inside a closure.",
+		FullMarkdown: "Range variables in a loop are reused at each iteration; therefore a goroutine created in a loop will point to the range variable\nwith from the upper scope. This way, the goroutine could use the variable with an undesired value.\nThis rule warns when a range value (or index) is used inside a closure.",
+	},
+	"receiver-naming": {
+		Short:        "Conventions around the naming of receivers.",
+		Full:         "By convention, receiver names in a method should reflect their identity. For example, if the receiver is of type `Parts`, `p` is an adequate name for it. Contrary to other languages, it is not idiomatic to name receivers as `this` or `self`.",
+		FullMarkdown: "By convention, receiver names in a method should reflect their identity.\nFor example, if the receiver is of type `Parts`, `p` is an adequate name for it.\nContrary to other languages, it is not idiomatic to name receivers as `this` or `self`.",
+	},
+	"redefines-builtin-id": {
+		Short:        "Warns on redefinitions of builtin identifiers",
+		Full:         "Constant names like `false`, `true`, `nil`, function names like `append`, `make`, and basic type names like `bool`, and `byte` are not reserved words of the language; therefore the can be redefined. Even if possible, redefining these built in names can lead to bugs very difficult to detect.",
+		FullMarkdown: "Constant names like `false`, `true`, `nil`, function names like `append`, `make`,\nand basic type names like `bool`, and `byte` are not reserved words of the language; therefore the can be redefined.\nEven if possible, redefining these built in names can lead to bugs very difficult to detect.",
+	},
+	"redundant-build-tag": {
+		Short:        "Warns about redundant `// +build` comment lines",
+		Full:         "This rule warns about redundant build tag comments `// +build` when `//go:build` is present. `gofmt` in Go 1.17+ automatically adds the `//go:build` constraint, making the `// +build` comment unnecessary.",
+		FullMarkdown: "This rule warns about redundant build tag comments `// +build` when `//go:build` is present.\n`gofmt` in Go 1.17+ automatically adds the `//go:build` constraint, making the `// +build` comment unnecessary.",
+	},
+	"redundant-import-alias": {
+		Short:        "Warns on import aliases matching the imported package name",
+		Full:         "This rule warns on redundant import aliases. This happens when the alias used on the import statement matches the imported package name.",
+		FullMarkdown: "This rule warns on redundant import aliases. This happens when the alias used on the import statement matches the imported package name.",
+	},
+	"redundant-test-main-exit": {
+		Short:        "Suggests removing `Exit` call in `TestMain` function for test files",
+		Full:         "This rule warns about redundant `Exit` calls in the `TestMain` function, as the Go test runner automatically handles program termination starting from Go 1.15.",
+		FullMarkdown: "This rule warns about redundant `Exit` calls in the `TestMain` function,\nas the Go test runner automatically handles program termination starting from Go 1.15.",
+	},
+	"string-format": {
+		Short:        "Warns on specific string literals that fail one or more user-configured regular expressions",
+		Full:         "This rule allows you to configure a list of regular expressions that string literals in certain function calls are checked against. This is geared towards user facing applications where string literals are often used for messages that will be presented to users, so it may be desirable to enforce consistent formatting.",
+		FullMarkdown: "This rule allows you to configure a list of regular expressions that string literals in certain function calls are checked against.\nThis is geared towards user facing applications where string literals are often used for messages that will be presented to users,\nso it may be desirable to enforce consistent formatting.",
+	},
+	"string-of-int": {
+		Short:        "Warns on suspicious casts from int to string",
+		Full:         "Explicit type conversion `string(i)` where `i` has an integer type other than `rune` might behave not as expected by the developer (e.g. `string(42)` is not `\"42\"`). This rule spot that kind of suspicious conversions.",
+		FullMarkdown: "Explicit type conversion `string(i)` where `i` has an integer type other than `rune` might behave not as expected by the developer\n(e.g. `string(42)` is not `\"42\"`). This rule spot that kind of suspicious conversions.",
+	},
+	"struct-tag": {
+		Short:        "Checks common struct tags like `json`, `xml`, `yaml`",
+		Full:         "Struct tags are not checked at compile time. This rule spots errors in struct tags of the following types: asn1, bson, datastore, default, json, mapstructure, properties, protobuf, required, toml, url, validate, xml, yaml.",
+		FullMarkdown: "Struct tags are not checked at compile time.\nThis rule spots errors in struct tags of the following types:\nasn1, bson, datastore, default, json, mapstructure, properties, protobuf, required, toml, url, validate, xml, yaml.",
+	},
+	"superfluous-else": {
+		Short:        "Prevents redundant else statements (extends [`indent-error-flow`](./RULES_DESCRIPTIONS.md#indent-error-flow))",
+		Full:         "To improve the readability of code, it is recommended to reduce the indentation as much as possible. This rule highlights redundant _else-blocks_ that can be eliminated from the code.",
+		FullMarkdown: "To improve the readability of code, it is recommended to reduce the indentation as much as possible.\nThis rule highlights redundant _else-blocks_ that can be eliminated from the code.",
+	},
+	"time-date": {
+		Short:        "Reports bad usage of `time.Date`.",
+		Full:         "Reports bad usage of `time.Date`.",
+		FullMarkdown: "Reports bad usage of `time.Date`.",
+	},
+	"time-equal": {
+		Short:        "Suggests to use `time.Time.Equal` instead of `==` and `!=` for equality check time.",
+		Full:         "This rule warns when using `==` and `!=` for equality check `time.Time` and suggest to `time.time.Equal` method, for about information follow this link",
+		FullMarkdown: "This rule warns when using `==` and `!=` for equality check `time.Time` and suggest to `time.time.Equal` method,\nfor about information follow [this link](https://pkg.go.dev/time#Time)",
+	},
+	"time-naming": {
+		Short:        "Conventions around the naming of time variables.",
+		Full:         "Using unit-specific suffix like \"Secs\", \"Mins\", ... when naming variables of type `time.Duration` can be misleading, this rule highlights those cases.",
+		FullMarkdown: "Using unit-specific suffix like \"Secs\", \"Mins\", ... when naming variables of type `time.Duration` can be misleading,\nthis rule highlights those cases.",
+	},
+	"unchecked-type-assertion": {
+		Short:        "Disallows type assertions without checking the result.",
+		Full:         "This rule checks whether a type assertion result is checked (the `ok` value), preventing unexpected `panic`s.",
+		FullMarkdown: "This rule checks whether a type assertion result is checked (the `ok` value), preventing unexpected `panic`s.",
+	},
+	"unconditional-recursion": {
+		Short:        "Warns on function calls that will lead to (direct) infinite recursion",
+		Full:         "Unconditional recursive calls will produce infinite recursion, thus program stack overflow. This rule detects and warns about unconditional (direct) recursive calls.",
+		FullMarkdown: "Unconditional recursive calls will produce infinite recursion, thus program stack overflow.\nThis rule detects and warns about unconditional (direct) recursive calls.",
+	},
+	"unexported-naming": {
+		Short:        "Warns on wrongly named un-exported symbols",
+		Full:         "this rule warns on wrongly named un-exported symbols, i.e. un-exported symbols whose name start with a capital letter.",
+		FullMarkdown: "this rule warns on wrongly named un-exported symbols, i.e. un-exported symbols whose name start with a capital letter.",
+	},
+	"unexported-return": {
+		Short:        "Warns when a public return is from unexported type.",
+		Full:         "This rule warns when an exported function or method returns a value of an un-exported type.",
+		FullMarkdown: "This rule warns when an exported function or method returns a value of an un-exported type.",
+	},
+	"unhandled-error": {
+		Short:        "Warns on unhandled errors returned by function calls",
+		Full:         "This rule warns when errors returned by a function are not explicitly handled on the caller side.",
+		FullMarkdown: "This rule warns when errors returned by a function are not explicitly handled on the caller side.",
+	},
+	"unnecessary-format": {
+		Short:        "Identifies calls to formatting functions where the format string does not contain any formatting verbs",
+		Full:         "This rule identifies calls to formatting functions where the format string does not contain any formatting verbs and recommends switching to the non-formatting, more efficient alternative.",
+		FullMarkdown: "This rule identifies calls to formatting functions where the format string does not contain any formatting verbs\nand recommends switching to the non-formatting, more efficient alternative.",
+	},
+	"unnecessary-stmt": {
+		Short:        "Suggests removing or simplifying unnecessary statements",
+		Full:         "This rule suggests to remove redundant statements like a `break` at the end of a case block, for improving the code's readability.",
+		FullMarkdown: "This rule suggests to remove redundant statements like a `break` at the end of a case block, for improving the code's readability.",
+	},
+	"unreachable-code": {
+		Short:        "Warns on unreachable code",
+		Full:         "This rule spots and proposes to remove unreachable code.",
+		FullMarkdown: "This rule spots and proposes to remove [unreachable code](https://en.wikipedia.org/wiki/Unreachable_code).",
+	},
+	"unused-parameter": {
+		Short:        "Suggests to rename or remove unused function parameters",
+		Full:         "This rule warns on unused parameters. Functions or methods with unused parameters can be a symptom of an unfinished refactoring or a bug.",
+		FullMarkdown: "This rule warns on unused parameters. Functions or methods with unused parameters can be a symptom of an unfinished refactoring or a bug.",
+	},
+	"unused-receiver": {
+		Short:        "Suggests to rename or remove unused method receivers",
+		Full:         "This rule warns on unused method receivers. Methods with unused receivers can be a symptom of an unfinished refactoring or a bug.",
+		FullMarkdown: "This rule warns on unused method receivers. Methods with unused receivers can be a symptom of an unfinished refactoring or a bug.",
+	},
+	"use-any": {
+		Short:        "Proposes to replace `interface{}` with its alias `any`",
+		Full:         "Since Go 1.18, `interface{}` has an alias: `any`. This rule proposes to replace instances of `interface{}` with `any`.",
+		FullMarkdown: "Since Go 1.18, `interface{}` has an alias: `any`. This rule proposes to replace instances of `interface{}` with `any`.",
+	},
+	"use-errors-new": {
+		Short:        "Spots calls to `fmt.Errorf` that can be replaced by `errors.New`",
+		Full:         "This rule identifies calls to `fmt.Errorf` that can be safely replaced by, the more efficient, `errors.New`.",
+		FullMarkdown: "This rule identifies calls to `fmt.Errorf` that can be safely replaced by, the more efficient, `errors.New`.",
+	},
+	"use-fmt-print": {
+		Short:        "Proposes to replace calls to built-in `print` and `println` with their equivalents from `fmt`.",
+		Full:         "This rule proposes to replace calls to built-in `print` and `println` with their equivalents from `fmt` standard package.",
+		FullMarkdown: "This rule proposes to replace calls to built-in `print` and `println` with their equivalents from `fmt` standard package.",
+	},
+	"useless-break": {
+		Short:        "Warns on useless `break` statements in case clauses",
+		Full:         "This rule warns on useless `break` statements in case clauses of switch and select statements. Go, unlike other programming languages like C, only executes statements of the selected case while ignoring the subsequent case clauses. Therefore, inserting a `break` at the end of a case clause has no effect.",
+		FullMarkdown: "This rule warns on useless `break` statements in case clauses of switch and select statements. Go,\nunlike other programming languages like C, only executes statements of the selected case while ignoring the subsequent case clauses.\nTherefore, inserting a `break` at the end of a case clause has no effect.",
+	},
+	"useless-fallthrough": {
+		Short:        "Warns on useless `fallthrough` statements in case clauses",
+		Full:         "This rule warns on useless `fallthrough` statements in case clauses of switch statements. A `fallthrough` is considered _useless_ if it's the single statement of a case clause block.",
+		FullMarkdown: "This rule warns on useless `fallthrough` statements in case clauses of switch statements.\nA `fallthrough` is considered _useless_ if it's the single statement of a case clause block.",
+	},
+	"var-declaration": {
+		Short:        "Reduces redundancies around variable declaration.",
+		Full:         "This rule proposes simplifications of variable declarations.",
+		FullMarkdown: "This rule proposes simplifications of variable declarations.",
+	},
+	"var-naming": {
+		Short:        "Naming rules.",
+		Full:         "This rule warns when initialism, variable or package naming conventions are not followed. It ignores functions starting with `Example`, `Test`, `Benchmark`, and `Fuzz` in test files, preserving `golint` original behavior.",
+		FullMarkdown: "This rule warns when [initialism](https://go.dev/wiki/CodeReviewComments#initialisms), [variable](https://go.dev/wiki/CodeReviewComments#variable-names)\nor [package](https://go.dev/wiki/CodeReviewComments#package-names) naming conventions are not followed.\nIt ignores functions starting with `Example`, `Test`, `Benchmark`, and `Fuzz` in test files, preserving `golint` original behavior.",
+	},
+	"waitgroup-by-value": {
+		Short:        "Warns on functions taking sync.WaitGroup as a by-value parameter",
+		Full:         "Function parameters that are passed by value, are in fact a copy of the original argument. Passing a copy of a `sync.WaitGroup` is usually not what the developer wants to do. This rule warns when a `sync.WaitGroup` expected as a by-value parameter in a function or method.",
+		FullMarkdown: "Function parameters that are passed by value, are in fact a copy of the original argument.\nPassing a copy of a `sync.WaitGroup` is usually not what the developer wants to do.\nThis rule warns when a `sync.WaitGroup` expected as a by-value parameter in a function or method.",
+	},
+}
@@ -0,0 +1,20 @@
+// Package ruledoc provides the descriptions of the rules, extracted from the documentation of revive.
+package ruledoc
+
+//go:generate go run generate.go
+
+// Description documents a rule.
+type Description struct {
+	// Short is the one-line description of the rule, from the list of rules of the README.
+	Short string
+	// Full is the description of the rule, from RULES_DESCRIPTIONS.md, in plain text.
+	Full string
+	// FullMarkdown is the description of the rule, from RULES_DESCRIPTIONS.md, in Markdown.
+	FullMarkdown string
+}
+
+// Of returns the description of the rule, and false if the rule is not documented.
+func Of(ruleName string) (Description, bool) {
+	d, ok := descriptions[ruleName]
+	return d, ok
+}
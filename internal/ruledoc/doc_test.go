@@ -0,0 +1,36 @@
+package ruledoc_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mgechev/revive/config"
+	"github.com/mgechev/revive/internal/ruledoc"
+)
+
+func TestOf(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "revive.toml")
+	if err := os.WriteFile(path, []byte("enableAllRules = true\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	conf, err := config.GetConfig(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rules, err := config.GetLintingRules(conf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, rule := range rules {
+		d, ok := ruledoc.Of(rule.Name())
+		if !ok {
+			t.Errorf("rule %s is not documented, run go generate ./internal/ruledoc after documenting it", rule.Name())
+			continue
+		}
+		if d.Short == "" || d.Full == "" || d.FullMarkdown == "" {
+			t.Errorf("rule %s has an incomplete description: %+v", rule.Name(), d)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+//go:build ignore
+
+// This program generates descriptions.go from README.md and RULES_DESCRIPTIONS.md.
+// Run it with "go generate ./internal/ruledoc" after changing the description of a rule.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mgechev/revive/internal/ruledoc"
+)
+
+var (
+	// | [`rule-name`](./RULES_DESCRIPTIONS.md#rule-name) | config | description | golint | typed |
+	readmeRow = regexp.MustCompile("^\\|\\s*\\[`([a-z0-9-]+)`\\]\\([^)]*\\)\\s*\\|[^|]*\\|([^|]*)\\|")
+	ruleTitle = regexp.MustCompile(`^## ([a-z0-9-]+)\s*$`)
+	mdLink    = regexp.MustCompile(`\[([^\]]*)\]\((?:[^()]|\([^)]*\))*\)`)
+)
+
+const descriptionPrefix = "_Description_:"
+
+func main() {
+	root := filepath.Join("..", "..")
+	descriptions := map[string]*ruledoc.Description{}
+	description := func(name string) *ruledoc.Description {
+		if descriptions[name] == nil {
+			descriptions[name] = &ruledoc.Description{}
+		}
+		return descriptions[name]
+	}
+
+	err := readLines(filepath.Join(root, "README.md"), func(lines []string) {
+		for _, line := range lines {
+			if m := readmeRow.FindStringSubmatch(line); m != nil {
+				description(m[1]).Short = strings.TrimSpace(m[2])
+			}
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = readLines(filepath.Join(root, "RULES_DESCRIPTIONS.md"), func(lines []string) {
+		rule := ""
+		for i := 0; i < len(lines); i++ {
+			if m := ruleTitle.FindStringSubmatch(lines[i]); m != nil {
+				rule = m[1]
+				continue
+			}
+			if rule == "" || !strings.HasPrefix(lines[i], descriptionPrefix) {
+				continue
+			}
+
+			paragraph := []string{strings.TrimSpace(strings.TrimPrefix(lines[i], descriptionPrefix))}
+			for i+1 < len(lines) && strings.TrimSpace(lines[i+1]) != "" {
+				i++
+				paragraph = append(paragraph, strings.TrimSpace(lines[i]))
+			}
+			markdown := strings.Join(paragraph, "\n")
+			d := description(rule)
+			d.FullMarkdown = markdown
+			d.Full = mdLink.ReplaceAllString(strings.Join(paragraph, " "), "$1")
+			rule = ""
+		}
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	names := make([]string, 0, len(descriptions))
+	for name := range descriptions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by generate.go; DO NOT EDIT.\n\npackage ruledoc\n\nvar descriptions = map[string]Description{\n")
+	for _, name := range names {
+		d := descriptions[name]
+		fmt.Fprintf(&buf, "%q: {\nShort: %q,\nFull: %q,\nFullMarkdown: %q,\n},\n", name, d.Short, d.Full, d.FullMarkdown)
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("descriptions.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func readLines(path string, fn func(lines []string)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+
+	fn(lines)
+	return nil
+}
@@ -0,0 +1,220 @@
+package lint
+
+import (
+	"context"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	goversion "github.com/hashicorp/go-version"
+)
+
+// Cache keeps the packages linted by a [Linter], with their failures and the reports of their files,
+// for the next runs to only lint the packages whose files changed. It is set with [Linter.UseCache].
+//
+// The parsed files and the type information of the packages stay in memory between the runs,
+// and the program rules are applied to all the packages on every run.
+// A cache is only valid for a configuration and a set of rules: a new one must be used when they change.
+// The [ExcludeRule] entries only count the failures of the packages linted again.
+//
+// A package linted again replaces the ones sharing a file with it, like the package before a file was added to it.
+// The other packages are kept until they are invalidated, unless the cache drops the unused ones, see [Cache.DropUnused].
+type Cache struct {
+	mu      sync.Mutex
+	entries map[string]*cacheEntry
+	// run is the number of the last run using the cache
+	run int
+	// dropUnused tells if the packages not used by a run are dropped once it completes
+	dropUnused bool
+}
+
+// cacheEntry is a package kept in a [Cache].
+type cacheEntry struct {
+	// files are the absolute paths of the files of the package, including the type-checked ones
+	files []string
+	// linted are the absolute paths of the linted files of the package
+	linted    []string
+	goVersion *goversion.Version
+	// pkg is nil if the package has no file to lint
+	pkg      *Package
+	failures []Failure
+	reports  []FileReport
+	// run is the number of the last run using the entry
+	run int
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{entries: map[string]*cacheEntry{}}
+}
+
+// UseCache makes the linter keep the packages it lints in the cache, and reuse the ones
+// of the previous runs instead of linting them again, unless they were invalidated.
+func (l *Linter) UseCache(cache *Cache) {
+	l.cache = cache
+}
+
+// DropUnused makes the cache drop the packages a run did not use once it completes, like the ones whose files were removed.
+// It suits the callers linting the same patterns on every run: the packages of the other patterns would be dropped too.
+func (c *Cache) DropUnused() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.dropUnused = true
+}
+
+// BypassCache makes the linter lint the packages of the files without the cache, neither reusing them nor keeping them,
+// like the packages of files whose content differs from the one of the files of the other runs.
+func (l *Linter) BypassCache(filenames ...string) {
+	for _, filename := range filenames {
+		if path, err := filepath.Abs(filename); err == nil {
+			l.uncached = append(l.uncached, path)
+		}
+	}
+}
+
+// bypassesCache tells if the package is linted without the cache.
+func (l *Linter) bypassesCache(pkgFiles PackageFiles) bool {
+	if len(l.uncached) == 0 {
+		return false
+	}
+	return slices.ContainsFunc(slices.Concat(pkgFiles.Files, pkgFiles.TypeCheckFiles), func(filename string) bool {
+		path, err := filepath.Abs(filename)
+		return err == nil && slices.Contains(l.uncached, path)
+	})
+}
+
+// Invalidate drops the packages of the files from the cache, for the next run to lint them again.
+// The packages whose files were added or removed are always linted again.
+func (c *Cache) Invalidate(filenames ...string) {
+	paths := make([]string, 0, len(filenames))
+	for _, filename := range filenames {
+		if path, err := filepath.Abs(filename); err == nil {
+			paths = append(paths, path)
+		}
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if slices.ContainsFunc(entry.files, func(file string) bool { return slices.Contains(paths, file) }) {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// Len returns the number of packages in the cache.
+func (c *Cache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.entries)
+}
+
+// begin starts a run, and returns its number.
+func (c *Cache) begin() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.run++
+	return c.run
+}
+
+// prune drops the packages the run did not use if the cache drops the unused ones.
+func (c *Cache) prune(run int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.dropUnused {
+		return
+	}
+	for key, entry := range c.entries {
+		if entry.run < run {
+			delete(c.entries, key)
+		}
+	}
+}
+
+// get returns the package of the files linted with the Go version, nil if it is not in the cache.
+func (c *Cache) get(key string, goVersion *goversion.Version, run int) *cacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	if !ok || !entry.goVersion.Equal(goVersion) {
+		return nil
+	}
+	entry.run = run
+	return entry
+}
+
+// put adds the package to the cache, replacing the ones sharing a linted file with it.
+func (c *Cache) put(key string, entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for otherKey, other := range c.entries {
+		if slices.ContainsFunc(other.linted, func(file string) bool { return slices.Contains(entry.linted, file) }) {
+			delete(c.entries, otherKey)
+		}
+	}
+	c.entries[key] = entry
+}
+
+// cacheKey returns the key of a package in a [Cache].
+func (pf PackageFiles) cacheKey() string {
+	return strings.Join(pf.Files, "\x00") + "\x01" + strings.Join(pf.TypeCheckFiles, "\x00")
+}
+
+// lintCachedPackage lints a package like lintPackage, unless it is in the cache: the reports of its files
+// and its failures are then the ones of the run that linted it.
+func (l *Linter) lintCachedPackage(ctx context.Context, pkgFiles PackageFiles, gover *goversion.Version, ruleSet []Rule, config Config, run int, failures chan Failure) (*Package, error) {
+	key := pkgFiles.cacheKey()
+	if entry := l.cache.get(key, gover, run); entry != nil {
+		for _, report := range entry.reports {
+			l.reportFile(report)
+		}
+		for _, failure := range entry.failures {
+			if err := sendFailure(ctx, failures, failure); err != nil {
+				return nil, err
+			}
+		}
+		return entry.pkg, nil
+	}
+
+	entry := &cacheEntry{goVersion: gover, run: run}
+	for i, filename := range slices.Concat(pkgFiles.Files, pkgFiles.TypeCheckFiles) {
+		if path, err := filepath.Abs(filename); err == nil {
+			entry.files = append(entry.files, path)
+			if i < len(pkgFiles.Files) {
+				entry.linted = append(entry.linted, path)
+			}
+		}
+	}
+
+	// the files of a package are reported one after the other, its failures concurrently
+	recorder := *l
+	recorder.onFile = func(report FileReport) {
+		entry.reports = append(entry.reports, report)
+		l.reportFile(report)
+	}
+	pkgFailures := make(chan Failure)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for failure := range pkgFailures {
+			entry.failures = append(entry.failures, failure)
+			// linting the package stops as well if the context is done
+			_ = sendFailure(ctx, failures, failure)
+		}
+	}()
+
+	pkg, err := recorder.lintPackage(ctx, pkgFiles, gover, ruleSet, config, pkgFailures)
+	close(pkgFailures)
+	<-done
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	entry.pkg = pkg
+	l.cache.put(key, entry)
+	return pkg, nil
+}
@@ -0,0 +1,104 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// countingRule reports the identifiers named x, counting the files it is applied to.
+type countingRule struct {
+	identRule
+	applied atomic.Int64
+}
+
+func (r *countingRule) Apply(file *File, args Arguments) []Failure {
+	r.applied.Add(1)
+	return r.identRule.Apply(file, args)
+}
+
+func TestCache(t *testing.T) {
+	dir := t.TempDir()
+	var packages []PackageFiles
+	for _, name := range []string{"a", "b"} {
+		filename := filepath.Join(dir, name, name+".go")
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte("package "+name+"\n\nvar x = 1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		packages = append(packages, PackageFiles{Files: []string{filename}})
+	}
+
+	rule := &countingRule{}
+	cache := NewCache()
+	lint := func(packages []PackageFiles) (failures, files []string) {
+		var mu sync.Mutex
+		l := New(os.ReadFile, 0)
+		l.UseCache(cache)
+		l.OnFile(func(report FileReport) {
+			mu.Lock()
+			defer mu.Unlock()
+			files = append(files, filepath.Base(report.Name))
+		})
+		ch, err := l.LintPackages(context.Background(), packages, []Rule{rule, &packageCountRule{}}, Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for failure := range ch {
+			failures = append(failures, filepath.Base(failure.Filename())+": "+failure.Failure)
+		}
+		slices.Sort(failures)
+		slices.Sort(files)
+		return failures, files
+	}
+
+	wantFailures := []string{"a.go: identifier x", "a.go: one of 2 packages", "b.go: identifier x", "b.go: one of 2 packages"}
+	for i, step := range []struct {
+		name       string
+		invalidate []string
+		applied    int64
+	}{
+		{name: "cold", applied: 2},
+		{name: "warm", applied: 0},
+		{name: "invalidated", invalidate: []string{packages[0].Files[0]}, applied: 1},
+		{name: "invalidated by an unknown file", invalidate: []string{filepath.Join(dir, "c.go")}, applied: 0},
+	} {
+		rule.applied.Store(0)
+		cache.Invalidate(step.invalidate...)
+		failures, files := lint(packages)
+		if !slices.Equal(failures, wantFailures) {
+			t.Errorf("step %d (%s): got failures %v, want %v", i, step.name, failures, wantFailures)
+		}
+		if want := []string{"a.go", "b.go"}; !slices.Equal(files, want) {
+			t.Errorf("step %d (%s): got files %v, want %v", i, step.name, files, want)
+		}
+		if got := rule.applied.Load(); got != step.applied {
+			t.Errorf("step %d (%s): the rule was applied to %d files, want %d", i, step.name, got, step.applied)
+		}
+	}
+
+	// the packages that are not linted anymore are kept, unless the cache drops the unused ones
+	failures, _ := lint(packages[1:])
+	if want := []string{"b.go: identifier x", "b.go: one of 1 packages"}; !slices.Equal(failures, want) {
+		t.Errorf("got failures %v, want %v", failures, want)
+	}
+	if got := cache.Len(); got != 2 {
+		t.Errorf("got %d cached packages, want 2", got)
+	}
+	rule.applied.Store(0)
+	if lint(packages); rule.applied.Load() != 0 {
+		t.Errorf("the rule was applied to %d files, want none", rule.applied.Load())
+	}
+
+	cache.DropUnused()
+	lint(packages[1:])
+	if got := cache.Len(); got != 1 {
+		t.Errorf("got %d cached packages, want 1", got)
+	}
+}
@@ -0,0 +1,219 @@
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// CodeOwners are the owners of the files of a repository, read from a CODEOWNERS file
+// with the syntax of GitHub or GitLab.
+//
+// As on GitHub, the last pattern matching a file gives its owners. The owners of the
+// GitLab sections are combined, the last pattern of each section matching the file giving its owners
+// in the section, or the default owners of the section if the pattern has none.
+type CodeOwners struct {
+	// root is the absolute path of the directory the patterns are relative to
+	root     string
+	sections []codeOwnersSection
+}
+
+type codeOwnersSection struct {
+	defaultOwners []string
+	entries       []codeOwnersEntry
+}
+
+type codeOwnersEntry struct {
+	patterns []*regexp.Regexp
+	owners   []string
+}
+
+// ParseCodeOwners parses the content of a CODEOWNERS file whose patterns are relative to the root directory.
+func ParseCodeOwners(root string, content []byte) (*CodeOwners, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &CodeOwners{root: root, sections: []codeOwnersSection{{}}}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			section, err := parseCodeOwnersSection(line)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CODEOWNERS line %d: %w", number, err)
+			}
+			result.sections = append(result.sections, section)
+			continue
+		}
+
+		entry, err := parseCodeOwnersEntry(line)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CODEOWNERS line %d: %w", number, err)
+		}
+		last := &result.sections[len(result.sections)-1]
+		last.entries = append(last.entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// parseCodeOwnersSection parses the header of a GitLab section, like ^[Section name][2] @owner.
+func parseCodeOwnersSection(line string) (codeOwnersSection, error) {
+	line = strings.TrimPrefix(line, "^")
+	end := strings.Index(line, "]")
+	if end < 0 {
+		return codeOwnersSection{}, fmt.Errorf("unterminated section %s", line)
+	}
+	rest := line[end+1:]
+	if strings.HasPrefix(rest, "[") {
+		// the number of approvals required by the section
+		approvalsEnd := strings.Index(rest, "]")
+		if approvalsEnd < 0 {
+			return codeOwnersSection{}, fmt.Errorf("unterminated number of approvals %s", line)
+		}
+		rest = rest[approvalsEnd+1:]
+	}
+
+	return codeOwnersSection{defaultOwners: codeOwnersList(strings.Fields(rest))}, nil
+}
+
+// parseCodeOwnersEntry parses a pattern followed by its owners.
+func parseCodeOwnersEntry(line string) (codeOwnersEntry, error) {
+	fields := strings.Fields(line)
+	pattern := fields[0]
+	if strings.HasPrefix(pattern, "!") {
+		return codeOwnersEntry{}, fmt.Errorf("negated pattern %s", pattern)
+	}
+
+	directory := strings.HasSuffix(pattern, "/")
+	pattern = strings.TrimSuffix(pattern, "/")
+	anchored := strings.Contains(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if !anchored {
+		pattern = "**/" + pattern
+	}
+
+	var globs []string
+	if !directory {
+		globs = append(globs, pattern)
+	}
+	if base := pattern[strings.LastIndex(pattern, "/")+1:]; directory || !strings.Contains(base, "*") {
+		// the pattern can name a directory, it then matches the files under it;
+		// a pattern ending with a wildcard only matches the files of its directory
+		globs = append(globs, pattern+"/**")
+	}
+
+	entry := codeOwnersEntry{owners: codeOwnersList(fields[1:])}
+	for _, glob := range globs {
+		rx, err := regexp.Compile(codeOwnersRegexp(glob))
+		if err != nil {
+			return codeOwnersEntry{}, fmt.Errorf("invalid pattern %s: %w", fields[0], err)
+		}
+		entry.patterns = append(entry.patterns, rx)
+	}
+	return entry, nil
+}
+
+// codeOwnersRegexp returns the regular expression matching the slash-separated paths matched by a glob
+// of a CODEOWNERS file: * matches any sequence of characters but /, ? any character but /,
+// ** any sequence of characters, and **/ any number of directories, including none.
+// The other characters are literal.
+func codeOwnersRegexp(glob string) string {
+	var rx strings.Builder
+	rx.WriteByte('^')
+	for glob != "" {
+		i := strings.IndexAny(glob, "*?")
+		if i < 0 {
+			rx.WriteString(regexp.QuoteMeta(glob))
+			break
+		}
+		rx.WriteString(regexp.QuoteMeta(glob[:i]))
+		glob = glob[i:]
+
+		switch {
+		case strings.HasPrefix(glob, "**/"):
+			rx.WriteString(`(?:[\s\S]*/)?`)
+			glob = glob[3:]
+		case strings.HasPrefix(glob, "**"):
+			rx.WriteString(`[\s\S]*`)
+			glob = glob[2:]
+		case glob[0] == '*':
+			rx.WriteString(`[^/]*`)
+			glob = glob[1:]
+		default:
+			rx.WriteString(`[^/]`)
+			glob = glob[1:]
+		}
+	}
+	rx.WriteByte('$')
+	return rx.String()
+}
+
+// codeOwnersList returns the owners of the fields, up to a comment.
+func codeOwnersList(fields []string) []string {
+	for i, field := range fields {
+		if strings.HasPrefix(field, "#") {
+			return fields[:i]
+		}
+	}
+	return fields
+}
+
+// Owners returns the owners of the file, nil if it has none or if it is outside of the root directory.
+func (co *CodeOwners) Owners(filename string) []string {
+	path, err := filepath.Abs(filename)
+	if err != nil {
+		return nil
+	}
+	path, err = filepath.Rel(co.root, path)
+	if err != nil || path == ".." || strings.HasPrefix(path, ".."+string(filepath.Separator)) {
+		return nil
+	}
+	path = filepath.ToSlash(path)
+
+	var owners []string
+	for _, section := range co.sections {
+		for _, entry := range slices.Backward(section.entries) {
+			if !entry.matches(path) {
+				continue
+			}
+			matched := entry.owners
+			if len(matched) == 0 {
+				matched = section.defaultOwners
+			}
+			for _, owner := range matched {
+				if !slices.Contains(owners, owner) {
+					owners = append(owners, owner)
+				}
+			}
+			break
+		}
+	}
+	return owners
+}
+
+func (e codeOwnersEntry) matches(path string) bool {
+	return slices.ContainsFunc(e.patterns, func(rx *regexp.Regexp) bool {
+		return rx.MatchString(path)
+	})
+}
+
+// HasOwner tells if the owner is one of the owners, GitHub owners being case insensitive.
+func HasOwner(owners []string, owner string) bool {
+	return slices.ContainsFunc(owners, func(o string) bool {
+		return strings.EqualFold(o, owner)
+	})
+}
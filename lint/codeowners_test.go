@@ -0,0 +1,114 @@
+package lint_test
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestCodeOwners(t *testing.T) {
+	root := t.TempDir()
+	content := `# comment
+*            @all
+*.md         @docs # trailing comment
+/build/logs/ @build
+docs/*       @writers
+apps/        @apps
+/scripts     @scripts
+/vendor/
+
+[Backend][2] @backend
+internal/
+internal/api/ @api
+^[Optional]
+**/*_test.go @qa
+`
+	co, err := lint.ParseCodeOwners(root, []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"main.go", []string{"@all"}},
+		{"README.md", []string{"@docs"}},
+		{"pkg/README.md", []string{"@docs"}},
+		{"build/logs/a/b.go", []string{"@build"}},
+		{"x/build/logs/b.go", []string{"@all"}},
+		{"docs/a.go", []string{"@writers"}},
+		{"docs/sub/a.go", []string{"@all"}},
+		{"apps/a.go", []string{"@apps"}},
+		{"x/apps/y/a.go", []string{"@apps"}},
+		{"myapps/a.go", []string{"@all"}},
+		{"scripts/run.go", []string{"@scripts"}},
+		{"scripts", []string{"@scripts"}},
+		{"vendor/a/a.go", nil},
+		{"internal/a.go", []string{"@all", "@backend"}},
+		{"internal/api/a.go", []string{"@all", "@api"}},
+		{"internal/api/a_test.go", []string{"@all", "@api", "@qa"}},
+	}
+	for _, tt := range tests {
+		if got := co.Owners(filepath.Join(root, filepath.FromSlash(tt.path))); !slices.Equal(got, tt.want) {
+			t.Errorf("owners of %s: got %v, want %v", tt.path, got, tt.want)
+		}
+	}
+
+	if got := co.Owners(filepath.Join(filepath.Dir(root), "outside.go")); got != nil {
+		t.Errorf("owners of a file outside of the root: got %v, want none", got)
+	}
+}
+
+func TestCodeOwnersPatterns(t *testing.T) {
+	root := t.TempDir()
+	content := `gen+/*.go  @gen
+a/?.go     @single
+(c)|d$.go  @literal
+**/main.go @main
+`
+	co, err := lint.ParseCodeOwners(root, []byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"gen+/x.go":     {"@gen"},
+		"genn/x.go":     nil,
+		"a/b.go":        {"@single"},
+		"a/bc.go":       nil,
+		"x/(c)|d$.go":   {"@literal"},
+		"d$.go":         nil,
+		"main.go":       {"@main"},
+		"cmd/main.go":   {"@main"},
+		"cmd/domain.go": nil,
+	} {
+		if got := co.Owners(filepath.Join(root, filepath.FromSlash(path))); !slices.Equal(got, want) {
+			t.Errorf("owners of %s: got %v, want %v", path, got, want)
+		}
+	}
+}
+
+func TestParseCodeOwnersErrors(t *testing.T) {
+	for _, content := range []string{
+		"!negated.go @team",
+		"[Unterminated @team",
+		"[Section][2 @team",
+	} {
+		if _, err := lint.ParseCodeOwners(".", []byte(content)); err == nil {
+			t.Errorf("expected an error for %q", content)
+		}
+	}
+}
+
+func TestHasOwner(t *testing.T) {
+	owners := []string{"@org/Team", "user@example.com"}
+	if !lint.HasOwner(owners, "@org/team") {
+		t.Error("owners should be case insensitive")
+	}
+	if lint.HasOwner(owners, "@org/other") {
+		t.Error("@org/other is not an owner")
+	}
+}
@@ -1,6 +1,8 @@
 package lint
 
 import (
+	"fmt"
+
 	goversion "github.com/hashicorp/go-version"
 )
 
@@ -17,6 +19,8 @@ type RuleConfig struct {
 	Disabled  bool
 	// Exclude - rule-level file excludes, TOML related (strings)
 	Exclude []string
+	// Generated - if the rule lints the files containing generated code, it skips them by default
+	Generated GeneratedMode
 	// excludeFilters - regex-based file filters, initialized from Exclude
 	excludeFilters []*FileFilter
 }
@@ -30,6 +34,11 @@ func (rc *RuleConfig) Initialize() error {
 		}
 		rc.excludeFilters = append(rc.excludeFilters, ff)
 	}
+	switch rc.Generated {
+	case "", GeneratedSkip, GeneratedLint:
+	default:
+		return fmt.Errorf("invalid generated mode %q, expected %q or %q", rc.Generated, GeneratedSkip, GeneratedLint)
+	}
 	return nil
 }
 
@@ -46,6 +55,11 @@ func (rc *RuleConfig) MustExclude(name string) bool {
 	return false
 }
 
+// LintsGenerated tells if the rule lints the files containing generated code.
+func (rc *RuleConfig) LintsGenerated() bool {
+	return rc.Generated == GeneratedLint
+}
+
 // DirectiveConfig is type used for the linter directive configuration.
 type DirectiveConfig struct {
 	Severity Severity
@@ -57,15 +71,42 @@ type DirectivesConfig = map[string]DirectiveConfig
 // Config defines the config of the linter.
 type Config struct {
 	IgnoreGeneratedHeader bool `toml:"ignoreGeneratedHeader"`
-	Confidence            float64
-	Severity              Severity
-	EnableAllRules        bool             `toml:"enableAllRules"`
-	Rules                 RulesConfig      `toml:"rule"`
-	ErrorCode             int              `toml:"errorCode"`
-	WarningCode           int              `toml:"warningCode"`
-	Directives            DirectivesConfig `toml:"directive"`
-	Exclude               []string         `toml:"exclude"`
+	// Generated - how the files containing generated code are detected, in addition to their standard header
+	Generated      GeneratedConfig `toml:"generated"`
+	Confidence     float64
+	Severity       Severity
+	EnableAllRules bool             `toml:"enableAllRules"`
+	Rules          RulesConfig      `toml:"rule"`
+	ErrorCode      int              `toml:"errorCode"`
+	WarningCode    int              `toml:"warningCode"`
+	Directives     DirectivesConfig `toml:"directive"`
+	Exclude        []string         `toml:"exclude"`
+	// ExcludeRules - the [[exclude-rule]] entries, excluding the failures matching their criteria
+	ExcludeRules []ExcludeRule `toml:"exclude-rule"`
 	// If set, overrides the go language version specified in go.mod of
 	// packages being linted, and assumes this specific language version.
+	// The //go:build constraints of files can still set the version of a file.
 	GoVersion *goversion.Version
+	// Outputs - where the failures are written, and with which formatters
+	Outputs []OutputConfig `toml:"output"`
+	// Formatters - the options of the formatters, by formatter name
+	Formatters FormattersConfig `toml:"formatter"`
+	// CodeOwners - the path of the CODEOWNERS file giving the owners of the failures;
+	// if empty, the file is looked for in the repository of the working directory
+	CodeOwners string `toml:"codeowners"`
+}
+
+// FormatterConfig is type used for the options of a formatter.
+// Each formatter defines the options it supports.
+type FormatterConfig = map[string]any
+
+// FormattersConfig defines the config for all formatters.
+type FormattersConfig = map[string]FormatterConfig
+
+// OutputConfig is type used for the configuration of an output.
+type OutputConfig struct {
+	// Formatter is the name of the formatter, the default one if empty.
+	Formatter string `toml:"formatter"`
+	// Path is the file where the failures are written, the standard output if empty.
+	Path string `toml:"path"`
 }
@@ -0,0 +1,182 @@
+package lint
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"regexp"
+	"slices"
+	"strings"
+	"sync/atomic"
+
+	"github.com/mgechev/revive/internal/typeparams"
+)
+
+// ExcludeRule is an [[exclude-rule]] entry of the configuration: it excludes the failures
+// matching all the criteria it sets. For example, the following entry excludes the failures
+// of add-constant in the test functions:
+//
+//	[[exclude-rule]]
+//	rule = "add-constant"
+//	symbol = "^Test"
+//	reason = "tests use literal values"
+type ExcludeRule struct {
+	// Rule is the name of the rule of the failures, or a former name of the rule.
+	Rule string `toml:"rule"`
+	// Path is a file filter of the file of the failures, like the ones of the rule-level file excludes:
+	// a path, a glob like cmd/**, or a regular expression prefixed by ~.
+	Path string `toml:"path"`
+	// Message is a regular expression matching the messages of the failures.
+	Message string `toml:"message"`
+	// Category is the category of the failures.
+	Category FailureCategory `toml:"category"`
+	// Symbol is a regular expression matching the name of the declaration enclosing the failures:
+	// the name of a function, Type.Method for a method, or the name of a type.
+	Symbol string `toml:"symbol"`
+	// Reason tells why the failures are excluded.
+	Reason string `toml:"reason"`
+
+	path    *FileFilter
+	message *regexp.Regexp
+	symbol  *regexp.Regexp
+	// matches counts the failures matched since the initialization of the entry
+	matches *atomic.Int64
+}
+
+// Initialize should be called after reading from TOML file.
+// An entry that is not initialized matches no failure.
+func (er *ExcludeRule) Initialize() error {
+	if er.Rule == "" && er.Path == "" && er.Message == "" && er.Category == "" && er.Symbol == "" {
+		return errors.New("the entry matches all the failures: set at least one of rule, path, message, category and symbol")
+	}
+
+	er.Rule = ActualRuleName(er.Rule)
+	var err error
+	if er.Path != "" {
+		if er.path, err = ParseFileFilter(er.Path); err != nil {
+			return err
+		}
+	}
+	if er.Message != "" {
+		if er.message, err = regexp.Compile(er.Message); err != nil {
+			return fmt.Errorf("invalid message regular expression: %w", err)
+		}
+	}
+	if er.Symbol != "" {
+		if er.symbol, err = regexp.Compile(er.Symbol); err != nil {
+			return fmt.Errorf("invalid symbol regular expression: %w", err)
+		}
+	}
+	er.matches = &atomic.Int64{}
+	return nil
+}
+
+// Matches returns the number of failures the entry excluded since its initialization.
+func (er *ExcludeRule) Matches() int64 {
+	if er.matches == nil {
+		return 0
+	}
+	return er.matches.Load()
+}
+
+// CloneExcludeRules returns a copy of the [[exclude-rule]] entries counting their matches from zero,
+// for a run to count its own matches while other runs use the entries.
+func CloneExcludeRules(excludeRules []ExcludeRule) []ExcludeRule {
+	clones := slices.Clone(excludeRules)
+	for i := range clones {
+		if clones[i].matches != nil {
+			clones[i].matches = &atomic.Int64{}
+		}
+	}
+	return clones
+}
+
+// String returns the criteria of the entry, like rule=add-constant symbol=^Test.
+func (er *ExcludeRule) String() string {
+	var criteria []string
+	for _, criterion := range []struct{ name, value string }{
+		{"rule", er.Rule},
+		{"path", er.Path},
+		{"message", er.Message},
+		{"category", string(er.Category)},
+		{"symbol", er.Symbol},
+	} {
+		if criterion.value != "" {
+			criteria = append(criteria, criterion.name+"="+criterion.value)
+		}
+	}
+	return strings.Join(criteria, " ")
+}
+
+// match tells if the entry matches the failure, counting the match.
+// symbol returns the name of the declaration enclosing the failure; it is only called if needed.
+func (er *ExcludeRule) match(failure Failure, symbol func() string) bool {
+	matched := er.matches != nil &&
+		(er.Rule == "" || er.Rule == failure.RuleName) &&
+		(er.Category == "" || er.Category == failure.Category) &&
+		(er.path == nil || er.path.MatchFileName(failure.Filename())) &&
+		(er.message == nil || er.message.MatchString(failure.Failure)) &&
+		(er.symbol == nil || er.symbol.MatchString(symbol()))
+	if matched {
+		er.matches.Add(1)
+	}
+	return matched
+}
+
+// isExcluded tells if an [[exclude-rule]] entry of the configuration matches the failure of the file.
+// The file is nil if unknown, the failure then has no enclosing declaration.
+// Every matching entry counts the match, for the entries matching no failure to be found.
+func (c *Config) isExcluded(failure Failure, file *File) bool {
+	var symbol *string
+	enclosingSymbol := func() string {
+		if symbol == nil {
+			name := file.enclosingSymbol(failure.Position.Start)
+			symbol = &name
+		}
+		return *symbol
+	}
+
+	excluded := false
+	for i := range c.ExcludeRules {
+		if c.ExcludeRules[i].match(failure, enclosingSymbol) {
+			excluded = true
+		}
+	}
+	return excluded
+}
+
+// enclosingSymbol returns the name of the declaration of the file enclosing the position:
+// the name of a function, Type.Method for a method, or the name of a type.
+// It returns an empty string if there is none.
+func (f *File) enclosingSymbol(position token.Position) string {
+	if f == nil || f.AST == nil || position.Filename != f.Name {
+		return ""
+	}
+	tokenFile := f.Pkg.fset.File(f.AST.Pos())
+	if tokenFile == nil || position.Offset < 0 || position.Offset > tokenFile.Size() {
+		return ""
+	}
+	pos := tokenFile.Pos(position.Offset)
+
+	for _, decl := range f.AST.Decls {
+		if pos < decl.Pos() || pos >= decl.End() {
+			continue
+		}
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				return typeparams.ReceiverType(decl) + "." + decl.Name.Name
+			}
+			return decl.Name.Name
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				if spec, ok := spec.(*ast.TypeSpec); ok && pos >= spec.Pos() && pos < spec.End() {
+					return spec.Name.Name
+				}
+			}
+		}
+		return ""
+	}
+	return ""
+}
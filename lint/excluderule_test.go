@@ -0,0 +1,137 @@
+package lint
+
+import (
+	"context"
+	"go/ast"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// identRule reports the identifiers named x.
+type identRule struct{}
+
+func (*identRule) Name() string { return "ident" }
+
+func (*identRule) Apply(file *File, _ Arguments) []Failure {
+	var failures []Failure
+	ast.Inspect(file.AST, func(n ast.Node) bool {
+		if ident, ok := n.(*ast.Ident); ok && ident.Name == "x" {
+			failures = append(failures, Failure{Failure: "identifier x", Category: "naming", Confidence: 1, Node: ident})
+		}
+		return true
+	})
+	return failures
+}
+
+func TestExcludeRules(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "a.go")
+	src := "package pkg\n\ntype T struct{ x int }\n\nfunc (*T) M() { x := 1; _ = x }\n\nfunc TestA() { x := 2; _ = x }\n\nvar x = 3\n"
+	if err := os.WriteFile(filename, []byte(src), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	excludeRules := []ExcludeRule{
+		{Symbol: "^Test", Reason: "tests"},
+		{Rule: "ident", Message: "^identifier", Symbol: `^T\.M$`},
+		{Rule: "ident", Category: "style"},
+		{Path: "other/**"},
+	}
+	for i := range excludeRules {
+		if err := excludeRules[i].Initialize(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := New(os.ReadFile, 0)
+	failures, err := l.LintPackages(context.Background(), []PackageFiles{{Files: []string{filename}}}, []Rule{&identRule{}}, Config{
+		ExcludeRules: excludeRules,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var lines []int
+	for failure := range failures {
+		lines = append(lines, failure.Position.Start.Line)
+	}
+	slices.Sort(lines)
+	if want := []int{3, 9}; !slices.Equal(lines, want) {
+		t.Errorf("got failures on lines %v, want %v", lines, want)
+	}
+
+	var matches []int64
+	for i := range excludeRules {
+		matches = append(matches, excludeRules[i].Matches())
+	}
+	if want := []int64{2, 2, 0, 0}; !slices.Equal(matches, want) {
+		t.Errorf("got matches %v, want %v", matches, want)
+	}
+}
+
+func TestExcludeRule_Initialize(t *testing.T) {
+	for _, excludeRule := range []ExcludeRule{
+		{Reason: "no criteria"},
+		{Message: "("},
+		{Symbol: "["},
+		{Path: "~("},
+	} {
+		if err := excludeRule.Initialize(); err == nil {
+			t.Errorf("expected an error for %+v", excludeRule)
+		}
+	}
+}
+
+func TestExcludeRule_String(t *testing.T) {
+	excludeRule := ExcludeRule{Rule: "unhandled-error", Path: "cmd/**", Message: `fmt\.Fprint`, Reason: "ignored"}
+	if got, want := excludeRule.String(), `rule=unhandled-error path=cmd/** message=fmt\.Fprint`; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestExcludeRulesLinterFailures(t *testing.T) {
+	dir := t.TempDir()
+	invalid := filepath.Join(dir, "invalid.go")
+	directive := filepath.Join(dir, "directive.go")
+	for name, src := range map[string]string{
+		invalid:   "package pkg\n\nfunc {\n",
+		directive: "package pkg\n\n//revive:disable-next-line:ident\nvar y = 1\n",
+	} {
+		if err := os.WriteFile(name, []byte(src), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	excludeRules := []ExcludeRule{
+		{Category: "validity", Path: "**/invalid.go"},
+		{Rule: DirectiveSpecifyDisableReason},
+		{Rule: "imports-blacklist"},
+	}
+	for i := range excludeRules {
+		if err := excludeRules[i].Initialize(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if got, want := excludeRules[2].Rule, "imports-blocklist"; got != want {
+		t.Errorf("got rule %q, want %q", got, want)
+	}
+
+	l := New(os.ReadFile, 0)
+	failures, err := l.LintPackages(context.Background(), []PackageFiles{{Files: []string{invalid, directive}}}, []Rule{&identRule{}}, Config{
+		Directives:   DirectivesConfig{DirectiveSpecifyDisableReason: {}},
+		ExcludeRules: excludeRules,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for failure := range failures {
+		t.Errorf("unexpected failure %q", failure.Failure)
+	}
+	for i, want := range []int64{1, 1, 0} {
+		if got := excludeRules[i].Matches(); got != want {
+			t.Errorf("got %d matches for entry #%d, want %d", got, i+1, want)
+		}
+	}
+}
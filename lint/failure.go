@@ -69,6 +69,8 @@ type FailurePosition struct {
 }
 
 // Failure defines a struct for a linting failure.
+//
+// Failures are not comparable, as they have slice fields: compare their fields instead.
 type Failure struct {
 	Failure    string
 	RuleName   string
@@ -78,6 +80,33 @@ type Failure struct {
 	Confidence float64
 	// For future use
 	ReplacementLine string
+	// Related are the other locations explaining the failure, like the other branch of identical branches.
+	Related []RelatedLocation
+	// Owners are the owners of the file of the failure, from the CODEOWNERS file of the repository, if any.
+	Owners []string
+	// FileContent is the content of the file of the failure, if known.
+	// It lets formatters show the source code of the failure.
+	FileContent []byte `json:"-"`
+}
+
+// RelatedLocation is a location related to a failure, with a message telling how it relates to the failure.
+type RelatedLocation struct {
+	Message  string
+	Position FailurePosition
+	// Node is the node of the location; when set, the linter sets the position from it.
+	Node ast.Node `json:"-"`
+}
+
+// setNodePositions sets the positions of the failure and of its related locations from their nodes, if any.
+func (f *Failure) setNodePositions(fset *token.FileSet) {
+	if f.Node != nil {
+		f.Position = FailurePosition{Start: fset.Position(f.Node.Pos()), End: fset.Position(f.Node.End())}
+	}
+	for i, related := range f.Related {
+		if related.Node != nil {
+			f.Related[i].Position = FailurePosition{Start: fset.Position(related.Node.Pos()), End: fset.Position(related.Node.End())}
+		}
+	}
 }
 
 // GetFilename returns the filename.
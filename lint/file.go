@@ -2,6 +2,7 @@ package lint
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"go/ast"
 	"go/parser"
@@ -11,6 +12,8 @@ import (
 	"math"
 	"regexp"
 	"strings"
+
+	goversion "github.com/hashicorp/go-version"
 )
 
 // File abstraction used for representing files.
@@ -19,6 +22,11 @@ type File struct {
 	Pkg     *Package
 	content []byte
 	AST     *ast.File
+	// disabled holds the intervals where rules are disabled by comment directives,
+	// available once the file is linted.
+	disabled disabledIntervalsMap
+	// generated tells if the file contains generated code, it is then only linted by the rules set to lint generated code.
+	generated bool
 }
 
 // IsTest returns if the file contains tests.
@@ -41,6 +49,37 @@ func (f *File) IsImportable() bool {
 	return true
 }
 
+// GoVersion returns the Go language version in effect for the file.
+//
+// It is the version of the file's package, unless a //go:build constraint of the file
+// requires a Go version. Then, like for the Go toolchain, it is that version or Go 1.21,
+// whichever is higher.
+func (f *File) GoVersion() *goversion.Version {
+	f.Pkg.mu.RLock()
+	pkgVersion := f.Pkg.goVersion
+	f.Pkg.mu.RUnlock()
+
+	if f.AST.GoVersion == "" {
+		return pkgVersion
+	}
+
+	fileVersion, err := parseGoVersion(f.AST.GoVersion)
+	if err != nil {
+		return pkgVersion
+	}
+
+	if fileVersion.LessThan(Go121) {
+		return Go121
+	}
+
+	return fileVersion
+}
+
+// IsAtLeastGoVersion returns true if the Go language version in effect for the file is v or higher, false otherwise.
+func (f *File) IsAtLeastGoVersion(v *goversion.Version) bool {
+	return f.GoVersion().GreaterThanOrEqual(v)
+}
+
 // Content returns the file's content.
 func (f *File) Content() []byte {
 	return f.content
@@ -112,15 +151,23 @@ func (f *File) isMain() bool {
 	return f.AST.Name.Name == "main"
 }
 
-const directiveSpecifyDisableReason = "specify-disable-reason"
+// DirectiveSpecifyDisableReason is the directive requiring the reason of the disabling directives,
+// and the rule name of the failures of the directives without a reason.
+const DirectiveSpecifyDisableReason = "specify-disable-reason"
 
-func (f *File) lint(rules []Rule, config Config, failures chan Failure) error {
+func (f *File) lint(ctx context.Context, rules []Rule, config Config, failures chan Failure) error {
 	rulesConfig := config.Rules
-	_, mustSpecifyDisableReason := config.Directives[directiveSpecifyDisableReason]
-	disabledIntervals := f.disabledIntervals(rules, mustSpecifyDisableReason, failures)
+	disabledIntervals := f.disabledIntervals(ctx, rules, config, failures)
+	f.disabled = disabledIntervals
 	for _, currentRule := range rules {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if !isFileRule(currentRule) {
+			continue
+		}
 		ruleConfig := rulesConfig[currentRule.Name()]
-		if ruleConfig.MustExclude(f.Name) {
+		if ruleConfig.MustExclude(f.Name) || (f.generated && !ruleConfig.LintsGenerated()) {
 			continue
 		}
 		currentFailures := currentRule.Apply(f, ruleConfig.Arguments)
@@ -132,15 +179,22 @@ func (f *File) lint(rules []Rule, config Config, failures chan Failure) error {
 			if failure.RuleName == "" {
 				failure.RuleName = currentRule.Name()
 			}
-			if failure.Node != nil {
-				failure.Position = ToFailurePosition(failure.Node.Pos(), failure.Node.End(), f)
+			failure.setNodePositions(f.Pkg.fset)
+			if failure.FileContent == nil && failure.Filename() == f.Name {
+				failure.FileContent = f.content
 			}
 			currentFailures[idx] = failure
 		}
 		currentFailures = f.filterFailures(currentFailures, disabledIntervals)
 		for _, failure := range currentFailures {
-			if failure.Confidence >= config.Confidence {
-				failures <- failure
+			if failure.Confidence < config.Confidence {
+				continue
+			}
+			if config.isExcluded(failure, f) {
+				continue
+			}
+			if err := sendFailure(ctx, failures, failure); err != nil {
+				return err
 			}
 		}
 	}
@@ -163,7 +217,8 @@ const (
 
 var directiveRegexp = regexp.MustCompile(`^//[\s]*revive:(enable|disable)(?:-(line|next-line))?(?::([^\s]+))?[\s]*(?: (.+))?$`)
 
-func (f *File) disabledIntervals(rules []Rule, mustSpecifyDisableReason bool, failures chan Failure) disabledIntervalsMap {
+func (f *File) disabledIntervals(ctx context.Context, rules []Rule, config Config, failures chan Failure) disabledIntervalsMap {
+	_, mustSpecifyDisableReason := config.Directives[DirectiveSpecifyDisableReason]
 	enabledDisabledRulesMap := map[string][]enableDisableConfig{}
 
 	getEnabledDisabledIntervals := func() disabledIntervalsMap {
@@ -246,13 +301,17 @@ func (f *File) disabledIntervals(rules []Rule, mustSpecifyDisableReason bool, fa
 
 			mustCheckDisablingReason := mustSpecifyDisableReason && match[directivePos] == "disable"
 			if mustCheckDisablingReason && strings.Trim(match[reasonPos], " ") == "" {
-				failures <- Failure{
+				failure := Failure{
 					Confidence: 1,
-					RuleName:   directiveSpecifyDisableReason,
+					RuleName:   DirectiveSpecifyDisableReason,
 					Failure:    "reason of lint disabling not found",
 					Position:   ToFailurePosition(c.Pos(), c.End(), f),
 					Node:       c,
 				}
+				if !config.isExcluded(failure, f) {
+					// an error here means the context is done, it will be reported by the caller
+					_ = sendFailure(ctx, failures, failure)
+				}
 				continue // skip this linter disabling directive
 			}
 
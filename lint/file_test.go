@@ -1,9 +1,12 @@
 package lint
 
 import (
+	"context"
 	"go/ast"
 	"go/token"
 	"testing"
+
+	goversion "github.com/hashicorp/go-version"
 )
 
 func TestFile_disabledIntervals(t *testing.T) {
@@ -157,7 +160,7 @@ func TestFile_disabledIntervals(t *testing.T) {
 					Comments: tt.comments,
 				},
 			}
-			got := f.disabledIntervals(nil, false, make(chan Failure, 10))
+			got := f.disabledIntervals(context.Background(), nil, Config{}, make(chan Failure, 10))
 			if len(got) != len(tt.expected) {
 				t.Errorf("disabledIntervals() = got %v, want %v", got, tt.expected)
 			}
@@ -180,3 +183,64 @@ func TestFile_disabledIntervals(t *testing.T) {
 		})
 	}
 }
+
+func TestFile_GoVersion(t *testing.T) {
+	tests := []struct {
+		name       string
+		src        string
+		pkgVersion string
+		expected   string
+	}{
+		{
+			name:       "package version",
+			src:        "package p\n",
+			pkgVersion: "1.20",
+			expected:   "1.20",
+		},
+		{
+			name:       "upgraded by build constraint",
+			src:        "//go:build go1.22\n\npackage p\n",
+			pkgVersion: "1.20",
+			expected:   "1.22",
+		},
+		{
+			name:       "downgraded by build constraint",
+			src:        "//go:build go1.21 && linux\n\npackage p\n",
+			pkgVersion: "1.23",
+			expected:   "1.21",
+		},
+		{
+			name:       "build constraint before Go 1.21",
+			src:        "//go:build go1.18\n\npackage p\n",
+			pkgVersion: "1.23",
+			expected:   "1.21",
+		},
+		{
+			name:       "build constraint without version",
+			src:        "//go:build linux\n\npackage p\n",
+			pkgVersion: "1.23",
+			expected:   "1.23",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkg := &Package{
+				fset:      token.NewFileSet(),
+				goVersion: goversion.Must(goversion.NewVersion(tt.pkgVersion)),
+			}
+			f, err := NewFile("test.go", []byte(tt.src), pkg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := f.GoVersion()
+			if !got.Equal(goversion.Must(goversion.NewVersion(tt.expected))) {
+				t.Errorf("expected version %v, got %v", tt.expected, got)
+			}
+			if !f.IsAtLeastGoVersion(got) {
+				t.Errorf("expected the file to be at least of version %v", got)
+			}
+		})
+	}
+}
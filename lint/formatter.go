@@ -1,5 +1,10 @@
 package lint
 
+import (
+	"io"
+	"sync"
+)
+
 // FormatterMetadata configuration of a formatter.
 type FormatterMetadata struct {
 	Name        string
@@ -12,3 +17,117 @@ type Formatter interface {
 	Format(<-chan Failure, Config) (string, error)
 	Name() string
 }
+
+// StreamFormatter defines an interface for failure formatters writing the failures as they arrive.
+//
+// For each run, Begin is called once, then Failure is called for each failure of the returned
+// [FailureWriter], and finally End is called once. Formatters that need all the failures,
+// e.g. to print a summary, keep what they need until End is called.
+type StreamFormatter interface {
+	// Begin starts a report written to w.
+	Begin(w io.Writer, config Config) (FailureWriter, error)
+	Name() string
+}
+
+// FailureWriter writes the failures of a report started by [StreamFormatter.Begin].
+type FailureWriter interface {
+	// Failure writes a failure.
+	Failure(Failure) error
+	// End completes the report, once all the failures are written. It must be called unless Failure,
+	// or File for a [FileWriter], returned an error, for the writer to release its resources.
+	End() error
+}
+
+// FileWriter is implemented by the [FailureWriter] of formatters reporting the files handled
+// by the linter, including the files without failures.
+type FileWriter interface {
+	// File records a file handled by the linter. It is called for each file once all the failures
+	// are written, before End.
+	File(FileReport) error
+}
+
+// OwnersFormatter is implemented by the formatters writing the owners of the failures,
+// for the CODEOWNERS file of the repository to only be read when a formatter needs it.
+type OwnersFormatter interface {
+	// WritesOwners tells if the formatter writes the owners of the failures.
+	WritesOwners() bool
+}
+
+// AsStreamFormatter returns the formatter as a [StreamFormatter].
+//
+// If the formatter only implements [Formatter], the report is written
+// once all the failures are formatted.
+func AsStreamFormatter(formatter Formatter) StreamFormatter {
+	if streamFormatter, ok := formatter.(StreamFormatter); ok {
+		return streamFormatter
+	}
+
+	return &formatterAdapter{formatter: formatter}
+}
+
+// formatterAdapter adapts a [Formatter] to the [StreamFormatter] interface.
+type formatterAdapter struct {
+	formatter Formatter
+}
+
+func (a *formatterAdapter) Name() string {
+	return a.formatter.Name()
+}
+
+func (a *formatterAdapter) Begin(w io.Writer, config Config) (FailureWriter, error) {
+	fw := &adaptedFailureWriter{
+		w:        w,
+		failures: make(chan Failure),
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		defer close(fw.done)
+		fw.output, fw.err = a.formatter.Format(fw.failures, config)
+	}()
+
+	return fw, nil
+}
+
+// adaptedFailureWriter sends the failures to the formatter, running in a goroutine
+// until it returns or the failures channel is closed.
+type adaptedFailureWriter struct {
+	w        io.Writer
+	failures chan Failure
+	// closeFailures closes the failures channel once, by End or on the first error
+	closeFailures sync.Once
+	// done is closed once the formatter returns, with output and err set
+	done   chan struct{}
+	output string
+	err    error
+}
+
+func (fw *adaptedFailureWriter) Failure(failure Failure) error {
+	select {
+	case <-fw.done:
+		// the formatter already returned, the failures channel may be closed
+		return fw.err
+	default:
+	}
+
+	select {
+	case fw.failures <- failure:
+		return nil
+	case <-fw.done:
+		// the formatter returned before reading all the failures: the report is over,
+		// even if End is not called
+		fw.closeFailures.Do(func() { close(fw.failures) })
+		return fw.err
+	}
+}
+
+func (fw *adaptedFailureWriter) End() error {
+	fw.closeFailures.Do(func() { close(fw.failures) })
+	<-fw.done
+	if fw.err != nil {
+		return fw.err
+	}
+
+	_, err := io.WriteString(fw.w, fw.output)
+	return err
+}
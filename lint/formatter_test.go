@@ -0,0 +1,94 @@
+package lint
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// countFormatter is a formatter implementing only the Formatter interface.
+type countFormatter struct {
+	err error
+	// firstOnly returns once the first failure is read, without error
+	firstOnly bool
+}
+
+func (*countFormatter) Name() string { return "count" }
+
+func (f *countFormatter) Format(failures <-chan Failure, _ Config) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+
+	var names []string
+	for failure := range failures {
+		names = append(names, failure.RuleName)
+		if f.firstOnly {
+			break
+		}
+	}
+	return strings.Join(names, ","), nil
+}
+
+func TestAsStreamFormatter(t *testing.T) {
+	t.Run("formatter", func(t *testing.T) {
+		var buf strings.Builder
+		fw, err := AsStreamFormatter(&countFormatter{}).Begin(&buf, Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{"a", "b"} {
+			if err := fw.Failure(Failure{RuleName: name}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := fw.End(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := buf.String(), "a,b"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("formatter returning early", func(t *testing.T) {
+		formatErr := errors.New("format error")
+		var buf strings.Builder
+		fw, err := AsStreamFormatter(&countFormatter{err: formatErr}).Begin(&buf, Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := fw.Failure(Failure{RuleName: "a"}); !errors.Is(err, formatErr) {
+			t.Errorf("got error %v, want %v", err, formatErr)
+		}
+		// the report is over after the error, even if End is not called
+		if _, open := <-fw.(*adaptedFailureWriter).failures; open {
+			t.Error("the failures channel is still open after the error")
+		}
+		if err := fw.End(); !errors.Is(err, formatErr) {
+			t.Errorf("got error %v, want %v", err, formatErr)
+		}
+		if buf.Len() > 0 {
+			t.Errorf("got output %q, want none", buf.String())
+		}
+	})
+	t.Run("formatter returning early without error", func(t *testing.T) {
+		var buf strings.Builder
+		fw, err := AsStreamFormatter(&countFormatter{firstOnly: true}).Begin(&buf, Config{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range []string{"a", "b", "c", "d"} {
+			if err := fw.Failure(Failure{RuleName: name}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := fw.End(); err != nil {
+			t.Fatal(err)
+		}
+
+		if got, want := buf.String(), "a"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+}
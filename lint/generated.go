@@ -0,0 +1,118 @@
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// GeneratedMode tells if a rule lints the files containing generated code.
+type GeneratedMode string
+
+const (
+	// GeneratedSkip skips the generated files, it is the default mode of the rules.
+	GeneratedSkip GeneratedMode = "skip"
+	// GeneratedLint lints the generated files, like for rules checking the security of the code.
+	GeneratedLint GeneratedMode = "lint"
+)
+
+// GeneratedConfig is the [generated] section of the configuration: it detects the files containing
+// generated code in addition to the standard header of https://golang.org/s/generatedcode.
+type GeneratedConfig struct {
+	// Files are file filters of the generated files, like *.pb.go or zz_generated.*;
+	// a glob without separator matches the base name of the files in any directory.
+	Files []string `toml:"files"`
+	// Headers are regular expressions matching a line of the header of the generated files,
+	// the lines before the package clause.
+	Headers []string `toml:"headers"`
+
+	files   []*FileFilter
+	headers []*regexp.Regexp
+}
+
+// Initialize should be called after reading from TOML file.
+func (gc *GeneratedConfig) Initialize() error {
+	for _, pattern := range gc.Files {
+		if !strings.HasPrefix(pattern, "~") && !strings.Contains(pattern, "/") {
+			pattern = "**/" + pattern
+		}
+		filter, err := ParseFileFilter(pattern)
+		if err != nil {
+			return err
+		}
+		gc.files = append(gc.files, filter)
+	}
+	for _, header := range gc.Headers {
+		rx, err := regexp.Compile(header)
+		if err != nil {
+			return fmt.Errorf("invalid header regular expression: %w", err)
+		}
+		gc.headers = append(gc.headers, rx)
+	}
+	return nil
+}
+
+// matchesFile tells if the name of the file matches one of the file filters.
+func (gc *GeneratedConfig) matchesFile(filename string) bool {
+	for _, filter := range gc.files {
+		if filter.MatchFileName(filename) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesHeader tells if a line of the header of the source file matches one of the header regular expressions.
+func (gc *GeneratedConfig) matchesHeader(src []byte) bool {
+	if len(gc.headers) == 0 {
+		return false
+	}
+
+	sc := bufio.NewScanner(bytes.NewReader(src))
+	for sc.Scan() {
+		line := sc.Bytes()
+		if bytes.HasPrefix(line, []byte("package ")) {
+			return false
+		}
+		for _, rx := range gc.headers {
+			if rx.Match(line) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isGenerated tells if the file contains generated code, detected by its standard header
+// unless ignoreGeneratedHeader is set, or by the [generated] section of the configuration.
+func (c *Config) isGenerated(filename string, src []byte) bool {
+	return (!c.IgnoreGeneratedHeader && isGenerated(src)) ||
+		c.Generated.matchesFile(filename) ||
+		c.Generated.matchesHeader(src)
+}
+
+// lintsGenerated tells if one of the rules lints the generated files.
+func (c *Config) lintsGenerated(rules []Rule) bool {
+	for _, rule := range rules {
+		ruleConfig := c.Rules[rule.Name()]
+		if ruleConfig.LintsGenerated() {
+			return true
+		}
+	}
+	return false
+}
+
+// fileRules returns the names of the rules linting the file, containing generated code or not.
+func (c *Config) fileRules(rules []Rule, filename string, generated bool) []string {
+	var names []string
+	for _, rule := range rules {
+		ruleConfig := c.Rules[rule.Name()]
+		if ruleConfig.MustExclude(filename) || (generated && !ruleConfig.LintsGenerated()) {
+			continue
+		}
+		names = append(names, rule.Name())
+	}
+	return names
+}
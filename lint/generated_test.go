@@ -0,0 +1,153 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestConfigIsGenerated(t *testing.T) {
+	generated := GeneratedConfig{
+		Files:   []string{"*.pb.go", "zz_generated.*", "mocks/**"},
+		Headers: []string{`^// Autogenerated by \w+`},
+	}
+	if err := generated.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		filename  string
+		source    string
+		ignore    bool
+		generated bool
+	}{
+		{filename: "a.go", source: "package a\n"},
+		{filename: "api/a.pb.go", source: "package api\n", generated: true},
+		{filename: "api/zz_generated.deepcopy.go", source: "package api\n", generated: true},
+		{filename: "mocks/sub/a.go", source: "package sub\n", generated: true},
+		{filename: "pkg/mocks/a.go", source: "package mocks\n"},
+		{filename: "a.go", source: "// Autogenerated by stringer\n\npackage a\n", generated: true},
+		{filename: "a.go", source: "package a\n\n// Autogenerated by stringer\n"},
+		{filename: "a.go", source: "// Code generated by stringer. DO NOT EDIT.\n\npackage a\n", generated: true},
+		{filename: "a.go", source: "// Code generated by stringer. DO NOT EDIT.\n\npackage a\n", ignore: true},
+		{filename: "a.pb.go", source: "package a\n", ignore: true, generated: true},
+	}
+
+	for _, tt := range tests {
+		config := Config{IgnoreGeneratedHeader: tt.ignore, Generated: generated}
+		if got := config.isGenerated(filepath.FromSlash(tt.filename), []byte(tt.source)); got != tt.generated {
+			t.Errorf("isGenerated(%q, %q) with ignoreGeneratedHeader=%v = %v, want %v", tt.filename, tt.source, tt.ignore, got, tt.generated)
+		}
+	}
+}
+
+func TestGeneratedConfig_Initialize(t *testing.T) {
+	for _, generated := range []GeneratedConfig{
+		{Files: []string{"~("}},
+		{Headers: []string{"["}},
+	} {
+		if err := generated.Initialize(); err == nil {
+			t.Errorf("expected an error for %+v", generated)
+		}
+	}
+}
+
+func TestRuleConfig_Generated(t *testing.T) {
+	for mode, valid := range map[GeneratedMode]bool{"": true, GeneratedSkip: true, GeneratedLint: true, "always": false} {
+		ruleConfig := RuleConfig{Generated: mode}
+		if err := ruleConfig.Initialize(); (err == nil) != valid {
+			t.Errorf("Initialize() with generated %q returned %v", mode, err)
+		}
+	}
+}
+
+func TestLintGeneratedFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go":    "package pkg\n\nvar x = 1\n",
+		"b.pb.go": "package pkg\n\nvar x = 2\n",
+		"c.go":    "// Code generated by hand. DO NOT EDIT.\n\npackage pkg\n\nvar x = 3\n",
+	}
+	var filenames []string
+	for name, content := range files {
+		filename := filepath.Join(dir, name)
+		if err := os.WriteFile(filename, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		filenames = append(filenames, filename)
+	}
+	slices.Sort(filenames)
+
+	config := Config{
+		Generated: GeneratedConfig{Files: []string{"*.pb.go"}},
+		Rules:     RulesConfig{},
+	}
+	if err := config.Generated.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	// fileRules are the rules linting each file, by file name
+	fileRules := map[string][]string{}
+	lint := func(config Config) (failures, generated, generatedLinted []string) {
+		var mu sync.Mutex
+		l := New(os.ReadFile, 0)
+		l.OnFile(func(report FileReport) {
+			mu.Lock()
+			defer mu.Unlock()
+			if report.Generated {
+				generated = append(generated, filepath.Base(report.Name))
+			}
+			if report.GeneratedLinted {
+				generatedLinted = append(generatedLinted, filepath.Base(report.Name))
+			}
+			fileRules[filepath.Base(report.Name)] = report.Rules
+		})
+		ch, err := l.LintPackages(context.Background(), []PackageFiles{{Files: filenames}}, []Rule{&identRule{}, &packageCountRule{}}, config)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for failure := range ch {
+			failures = append(failures, filepath.Base(failure.Filename())+": "+failure.RuleName)
+		}
+		slices.Sort(failures)
+		slices.Sort(generated)
+		slices.Sort(generatedLinted)
+		return failures, generated, generatedLinted
+	}
+
+	t.Run("skip", func(t *testing.T) {
+		failures, generated, generatedLinted := lint(config)
+		if want := []string{"a.go: ident", "a.go: package-count"}; !slices.Equal(failures, want) {
+			t.Errorf("got failures %v, want %v", failures, want)
+		}
+		if want := []string{"b.pb.go", "c.go"}; !slices.Equal(generated, want) {
+			t.Errorf("got generated files %v, want %v", generated, want)
+		}
+		if len(generatedLinted) != 0 {
+			t.Errorf("got generated linted files %v, want none", generatedLinted)
+		}
+	})
+
+	t.Run("lint", func(t *testing.T) {
+		config.Rules = RulesConfig{"ident": {Generated: GeneratedLint}}
+		failures, generated, generatedLinted := lint(config)
+		want := []string{"a.go: ident", "a.go: package-count", "b.pb.go: ident", "c.go: ident"}
+		if !slices.Equal(failures, want) {
+			t.Errorf("got failures %v, want %v", failures, want)
+		}
+		if len(generated) != 0 {
+			t.Errorf("got generated files %v, want none", generated)
+		}
+		if want := []string{"b.pb.go", "c.go"}; !slices.Equal(generatedLinted, want) {
+			t.Errorf("got generated linted files %v, want %v", generatedLinted, want)
+		}
+		for name, want := range map[string][]string{"a.go": {"ident", "package-count"}, "b.pb.go": {"ident"}, "c.go": {"ident"}} {
+			if got := fileRules[name]; !slices.Equal(got, want) {
+				t.Errorf("got rules %v for %s, want %v", got, name, want)
+			}
+		}
+	})
+}
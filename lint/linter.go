@@ -3,15 +3,24 @@ package lint
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"go/parser"
 	"go/token"
+	"go/version"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	goversion "github.com/hashicorp/go-version"
+	"github.com/spf13/afero"
 	"golang.org/x/mod/modfile"
 	"golang.org/x/sync/errgroup"
 )
@@ -23,6 +32,27 @@ type ReadFile func(path string) (result []byte, err error)
 type Linter struct {
 	reader         ReadFile
 	fileReadTokens chan struct{}
+	onFile         func(FileReport)
+	fs             afero.Fs
+	cache          *Cache
+	// uncached are the absolute paths of the files whose packages are linted without the cache
+	uncached []string
+}
+
+// FileReport describes how the linter handled a file.
+type FileReport struct {
+	Name string
+	// Generated is true if the file was skipped because it contains generated code.
+	Generated bool
+	// GeneratedLinted is true if the file contains generated code and was only linted by the rules
+	// set to lint generated code.
+	GeneratedLinted bool
+	// Invalid is true if the file was skipped because it cannot be parsed.
+	Invalid bool
+	// GoVersion is the Go language version in effect for the file, if it was linted.
+	GoVersion string
+	// Rules are the names of the rules linting the file, if it was linted.
+	Rules []string
 }
 
 // New creates a new Linter.
@@ -37,6 +67,28 @@ func New(reader ReadFile, maxOpenFiles int) Linter {
 	}
 }
 
+// OnFile registers a function to be called for every file handled by the linter,
+// including the ones skipped because they are generated or invalid.
+// The function can be called concurrently from several goroutines.
+func (l *Linter) OnFile(fn func(FileReport)) {
+	l.onFile = fn
+}
+
+// UseFileSystem makes the linter look for go.mod files in the given file system
+// instead of the one of the operating system. Relative paths of linted files
+// are then resolved from the root of fs.
+//
+// Files to lint are still read with the [ReadFile] given to [New].
+func (l *Linter) UseFileSystem(fs afero.Fs) {
+	l.fs = fs
+}
+
+func (l *Linter) reportFile(report FileReport) {
+	if l.onFile != nil {
+		l.onFile(report)
+	}
+}
+
 func (l Linter) readFile(path string) (result []byte, err error) {
 	if l.fileReadTokens != nil {
 		// "take" a token by writing to the channel.
@@ -59,61 +111,127 @@ var (
 
 // Lint lints a set of files with the specified rule.
 func (l *Linter) Lint(packages [][]string, ruleSet []Rule, config Config) (<-chan Failure, error) {
+	return l.LintContext(context.Background(), packages, ruleSet, config)
+}
+
+// LintContext lints a set of files with the specified rule.
+// Linting stops as soon as the context is done; the failures channel is then
+// closed without waiting for the remaining files to be linted.
+func (l *Linter) LintContext(ctx context.Context, packages [][]string, ruleSet []Rule, config Config) (<-chan Failure, error) {
+	pkgs := make([]PackageFiles, len(packages))
+	for i, files := range packages {
+		pkgs[i] = PackageFiles{Files: files}
+	}
+
+	return l.LintPackages(ctx, pkgs, ruleSet, config)
+}
+
+// PackageFiles holds the names of the files of a package.
+type PackageFiles struct {
+	// Files are the files to lint.
+	Files []string
+	// TypeCheckFiles are files type-checked along with Files but not linted.
+	// For example, the non-test files of a package for its in-package test files.
+	TypeCheckFiles []string
+}
+
+// LintPackages lints a set of packages with the specified rule.
+// Linting stops as soon as the context is done; the failures channel is then
+// closed without waiting for the remaining files to be linted.
+func (l *Linter) LintPackages(ctx context.Context, packages []PackageFiles, ruleSet []Rule, config Config) (<-chan Failure, error) {
 	failures := make(chan Failure)
 
-	perModVersions := map[string]*goversion.Version{}
+	// the packages are kept for program rules only, to release them as soon as possible otherwise
+	hasProgramRules := slices.ContainsFunc(ruleSet, func(rule Rule) bool {
+		_, ok := rule.(ProgramRule)
+		return ok
+	})
+
+	perDirVersions := map[string]*goversion.Version{}
 	perPkgVersions := make([]*goversion.Version, len(packages))
-	for n, files := range packages {
+	// the import paths of the packages are only needed to type-check the program
+	perPkgImportPaths := make([]string, len(packages))
+	for n, pkg := range packages {
+		files := pkg.Files
 		if len(files) == 0 {
 			continue
 		}
-		if config.GoVersion != nil {
-			perPkgVersions[n] = config.GoVersion
-			continue
-		}
 
-		dir, err := filepath.Abs(filepath.Dir(files[0]))
+		dir, err := l.modLookupDir(files[0])
 		if err != nil {
 			return nil, err
 		}
 
-		alreadyKnownMod := false
-		for d, v := range perModVersions {
-			if strings.HasPrefix(dir, d) {
-				perPkgVersions[n] = v
-				alreadyKnownMod = true
-				break
-			}
+		// a package with type-checked files is a test package, never imported
+		if hasProgramRules && len(pkg.TypeCheckFiles) == 0 {
+			perPkgImportPaths[n] = detectImportPath(l.modLookupFs(), dir)
 		}
-		if alreadyKnownMod {
+
+		if config.GoVersion != nil {
+			perPkgVersions[n] = config.GoVersion
 			continue
 		}
 
-		d, v, err := detectGoMod(dir)
-		if err != nil {
-			// No luck finding the go.mod file thus set the default Go version
-			v = defaultGoVersion
-			d = dir
+		v, ok := perDirVersions[dir]
+		if !ok {
+			v = detectGoVersion(l.modLookupFs(), dir)
+			perDirVersions[dir] = v
 		}
-		perModVersions[d] = v
 		perPkgVersions[n] = v
 	}
 
-	var wg errgroup.Group
+	var (
+		mu             sync.Mutex
+		lintedPackages []*Package
+		importPaths    = map[*Package]string{}
+	)
+
+	var run int
+	if l.cache != nil {
+		run = l.cache.begin()
+	}
+
+	// the packages left are not linted once the context is done or a package fails
+	wg, pkgCtx := errgroup.WithContext(ctx)
 	for n := range packages {
 		wg.Go(func() error {
+			if err := pkgCtx.Err(); err != nil {
+				return err
+			}
+
 			pkg := packages[n]
 			gover := perPkgVersions[n]
-			if err := l.lintPackage(pkg, gover, ruleSet, config, failures); err != nil {
+			var lintedPackage *Package
+			var err error
+			if l.cache != nil && !l.bypassesCache(pkg) {
+				lintedPackage, err = l.lintCachedPackage(pkgCtx, pkg, gover, ruleSet, config, run, failures)
+			} else {
+				lintedPackage, err = l.lintPackage(pkgCtx, pkg, gover, ruleSet, config, failures)
+			}
+			if err != nil {
 				return fmt.Errorf("error during linting: %w", err)
 			}
+			if hasProgramRules && lintedPackage != nil {
+				mu.Lock()
+				lintedPackages = append(lintedPackages, lintedPackage)
+				if perPkgImportPaths[n] != "" {
+					importPaths[lintedPackage] = perPkgImportPaths[n]
+				}
+				mu.Unlock()
+			}
 			return nil
 		})
 	}
 
 	go func() {
 		err := wg.Wait()
-		if err != nil {
+		if err == nil && l.cache != nil {
+			l.cache.prune(run)
+		}
+		if err == nil && hasProgramRules {
+			err = lintProgram(ctx, lintedPackages, importPaths, ruleSet, config, failures)
+		}
+		if err != nil && ctx.Err() == nil {
 			failures <- NewInternalFailure(err.Error())
 		}
 		close(failures)
@@ -122,9 +240,11 @@ func (l *Linter) Lint(packages [][]string, ruleSet []Rule, config Config) (<-cha
 	return failures, nil
 }
 
-func (l *Linter) lintPackage(filenames []string, gover *goversion.Version, ruleSet []Rule, config Config, failures chan Failure) error {
+// lintPackage lints the files of a package, and returns the package unless it has no file to lint.
+func (l *Linter) lintPackage(ctx context.Context, pkgFiles PackageFiles, gover *goversion.Version, ruleSet []Rule, config Config, failures chan Failure) (*Package, error) {
+	filenames := pkgFiles.Files
 	if len(filenames) == 0 {
-		return nil
+		return nil, nil
 	}
 
 	pkg := &Package{
@@ -132,37 +252,133 @@ func (l *Linter) lintPackage(filenames []string, gover *goversion.Version, ruleS
 		files:     map[string]*File{},
 		goVersion: gover,
 	}
+	lintsGenerated := config.lintsGenerated(ruleSet)
 	for _, filename := range filenames {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		content, err := l.readFile(filename)
 		if err != nil {
-			return err
+			return nil, err
 		}
-		if !config.IgnoreGeneratedHeader && isGenerated(content) {
+		generated := config.isGenerated(filename, content)
+		if generated && !lintsGenerated {
+			l.reportFile(FileReport{Name: filename, Generated: true})
 			continue
 		}
 
 		file, err := NewFile(filename, content, pkg)
 		if err != nil {
-			addInvalidFileFailure(filename, err.Error(), failures)
+			l.reportFile(FileReport{Name: filename, Invalid: true})
+			if err := addInvalidFileFailure(ctx, filename, content, err.Error(), config, failures); err != nil {
+				return nil, err
+			}
 			continue
 		}
+		file.generated = generated
 		pkg.files[filename] = file
+		l.reportFile(FileReport{
+			Name:            filename,
+			GoVersion:       file.GoVersion().Original(),
+			GeneratedLinted: generated,
+			Rules:           config.fileRules(ruleSet, filename, generated),
+		})
 	}
 
 	if len(pkg.files) == 0 {
-		return nil
+		return nil, nil
+	}
+
+	for _, filename := range pkgFiles.TypeCheckFiles {
+		content, err := l.readFile(filename)
+		if err != nil {
+			return nil, err
+		}
+
+		astFile, err := parser.ParseFile(pkg.fset, filename, content, parser.ParseComments)
+		if err != nil {
+			// invalid files are reported when linting the package they belong to
+			continue
+		}
+		pkg.typeCheckFiles = append(pkg.typeCheckFiles, astFile)
+	}
+
+	if err := pkg.lint(ctx, ruleSet, config, failures); err != nil {
+		return nil, err
+	}
+
+	return pkg, nil
+}
+
+// lintProgram applies the program rules to the linted packages, the importable ones having their import path.
+func lintProgram(ctx context.Context, packages []*Package, importPaths map[*Package]string, ruleSet []Rule, config Config, failures chan Failure) error {
+	firstFile := func(pkg *Package) string {
+		return slices.Min(slices.Collect(maps.Keys(pkg.Files())))
+	}
+	slices.SortFunc(packages, func(a, b *Package) int {
+		return strings.Compare(firstFile(a), firstFile(b))
+	})
+	program := newProgram(packages, importPaths)
+
+	for _, rule := range ruleSet {
+		programRule, ok := rule.(ProgramRule)
+		if !ok {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ruleFailures := programRule.ApplyProgram(program, config.Rules[rule.Name()].Arguments)
+		if err := sendRuleFailures(ctx, rule, ruleFailures, program.File, config, failures); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// modLookupDir returns the directory from where to start looking for the go.mod of a file.
+func (l *Linter) modLookupDir(filename string) (string, error) {
+	dir := filepath.Dir(filename)
+	if l.fs != nil {
+		return dir, nil
+	}
+
+	return filepath.Abs(dir)
+}
+
+func (l *Linter) modLookupFs() afero.Fs {
+	if l.fs != nil {
+		return l.fs
+	}
+
+	return afero.NewOsFs()
+}
+
+// detectGoVersion returns the Go language version of the module the directory belongs to.
+// Outside of a module, it is the version of the go.work workspace the directory belongs to,
+// or Go 1.0 if there is none.
+func detectGoVersion(fs afero.Fs, dir string) *goversion.Version {
+	if _, v, err := detectGoMod(fs, dir); err == nil {
+		return v
+	}
+
+	if v, err := detectGoWork(fs, dir); err == nil {
+		return v
 	}
 
-	return pkg.lint(ruleSet, config, failures)
+	return defaultGoVersion
 }
 
-func detectGoMod(dir string) (rootDir string, ver *goversion.Version, err error) {
-	modFileName, err := retrieveModFile(dir)
+func detectGoMod(fs afero.Fs, dir string) (rootDir string, ver *goversion.Version, err error) {
+	modFileName, err := retrieveModFile(fs, dir)
 	if err != nil {
 		return "", nil, fmt.Errorf("%q doesn't seem to be part of a Go module", dir)
 	}
 
-	mod, err := os.ReadFile(modFileName)
+	mod, err := afero.ReadFile(fs, modFileName)
 	if err != nil {
 		return "", nil, fmt.Errorf("failed to read %q, got %w", modFileName, err)
 	}
@@ -173,31 +389,101 @@ func detectGoMod(dir string) (rootDir string, ver *goversion.Version, err error)
 	}
 
 	if modAst.Go == nil {
-		return "", nil, fmt.Errorf("%q does not specify a Go version", modFileName)
+		// the packages of a module without go directive are linted with all the rules, as Go 1.0
+		return filepath.Dir(modFileName), defaultGoVersion, nil
 	}
 
-	ver, err = goversion.NewVersion(modAst.Go.Version)
+	// the toolchain directive doesn't change the language version, only the go directive does
+	ver, err = parseGoVersion(modAst.Go.Version)
 	return filepath.Dir(modFileName), ver, err
 }
 
-func retrieveModFile(dir string) (string, error) {
-	const lookingForFile = "go.mod"
+// detectImportPath returns the import path of the package of the directory, from the path of its module.
+// It returns an empty path if the directory is not part of a module.
+func detectImportPath(fs afero.Fs, dir string) string {
+	modFileName, err := retrieveModFile(fs, dir)
+	if err != nil {
+		return ""
+	}
+
+	mod, err := afero.ReadFile(fs, modFileName)
+	if err != nil {
+		return ""
+	}
+
+	modPath := modfile.ModulePath(mod)
+	rel, err := filepath.Rel(filepath.Dir(modFileName), dir)
+	if modPath == "" || err != nil {
+		return ""
+	}
+
+	return path.Join(modPath, filepath.ToSlash(rel))
+}
+
+// detectGoWork returns the Go version of the go.work workspace the directory belongs to.
+// Like the go command, it honours the GOWORK environment variable.
+func detectGoWork(fs afero.Fs, dir string) (*goversion.Version, error) {
+	workFileName := os.Getenv("GOWORK")
+	switch workFileName {
+	case "off":
+		return nil, errors.New("workspace mode is disabled")
+	case "", "auto":
+		var err error
+		workFileName, err = retrieveFile(fs, dir, "go.work")
+		if err != nil {
+			return nil, fmt.Errorf("%q doesn't seem to be part of a Go workspace", dir)
+		}
+	}
+
+	work, err := afero.ReadFile(fs, workFileName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %q, got %w", workFileName, err)
+	}
+
+	workAst, err := modfile.ParseWork(workFileName, work, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %q, got %w", workFileName, err)
+	}
+
+	if workAst.Go == nil {
+		return nil, fmt.Errorf("%q does not specify a Go version", workFileName)
+	}
+
+	return parseGoVersion(workAst.Go.Version)
+}
+
+// parseGoVersion returns the Go language version of a Go version,
+// e.g. 1.22 for 1.22.3 or 1.22rc1, with or without the "go" prefix.
+func parseGoVersion(v string) (*goversion.Version, error) {
+	lang := version.Lang("go" + strings.TrimPrefix(v, "go"))
+	if lang == "" {
+		return nil, fmt.Errorf("invalid Go version %q", v)
+	}
+
+	return goversion.NewVersion(strings.TrimPrefix(lang, "go"))
+}
+
+func retrieveModFile(fs afero.Fs, dir string) (string, error) {
+	return retrieveFile(fs, dir, "go.mod")
+}
+
+// retrieveFile looks for the file in the directory and its parents.
+func retrieveFile(fs afero.Fs, dir, lookingForFile string) (string, error) {
 	for {
+		lookingForFilePath := filepath.Join(dir, lookingForFile)
+		info, err := fs.Stat(lookingForFilePath)
+		if err == nil && !info.IsDir() {
+			return lookingForFilePath, nil
+		}
+
 		// filepath.Dir returns 'C:\' on Windows, and '/' on Unix
 		isRootDir := (dir == filepath.VolumeName(dir)+string(filepath.Separator))
 		if dir == "." || isRootDir {
 			return "", fmt.Errorf("did not found %q file", lookingForFile)
 		}
 
-		lookingForFilePath := filepath.Join(dir, lookingForFile)
-		info, err := os.Stat(lookingForFilePath)
-		if err != nil || info.IsDir() {
-			// lets check the parent dir
-			dir = filepath.Dir(dir)
-			continue
-		}
-
-		return lookingForFilePath, nil
+		// lets check the parent dir
+		dir = filepath.Dir(dir)
 	}
 }
 
@@ -215,14 +501,28 @@ func isGenerated(src []byte) bool {
 	return false
 }
 
-// addInvalidFileFailure adds a failure for an invalid formatted file.
-func addInvalidFileFailure(filename, errStr string, failures chan Failure) {
-	position := getPositionInvalidFile(filename, errStr)
-	failures <- Failure{
-		Confidence: 1,
-		Failure:    fmt.Sprintf("invalid file %s: %v", filename, errStr),
-		Category:   failureCategoryValidity,
-		Position:   position,
+// addInvalidFileFailure adds a failure for an invalid formatted file, unless an [[exclude-rule]] entry matches it.
+func addInvalidFileFailure(ctx context.Context, filename string, content []byte, errStr string, config Config, failures chan Failure) error {
+	failure := Failure{
+		Confidence:  1,
+		Failure:     fmt.Sprintf("invalid file %s: %v", filename, errStr),
+		Category:    failureCategoryValidity,
+		Position:    getPositionInvalidFile(filename, errStr),
+		FileContent: content,
+	}
+	if config.isExcluded(failure, nil) {
+		return nil
+	}
+	return sendFailure(ctx, failures, failure)
+}
+
+// sendFailure sends the failure to the channel, unless the context is done first.
+func sendFailure(ctx context.Context, failures chan<- Failure, failure Failure) error {
+	select {
+	case failures <- failure:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
 }
 
@@ -1,9 +1,18 @@
 package lint
 
 import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync/atomic"
 	"testing"
+
+	"github.com/spf13/afero"
 )
 
 func TestRetrieveModFile(t *testing.T) {
@@ -19,7 +28,7 @@ func TestRetrieveModFile(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		foundPath, err := retrieveModFile(nestedDir)
+		foundPath, err := retrieveModFile(afero.NewOsFs(), nestedDir)
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
 		}
@@ -29,7 +38,7 @@ func TestRetrieveModFile(t *testing.T) {
 	})
 
 	t.Run("go.mod file does not exist", func(t *testing.T) {
-		_, err := retrieveModFile(t.TempDir())
+		_, err := retrieveModFile(afero.NewOsFs(), t.TempDir())
 		if err == nil {
 			t.Fatal("expected error, got nil")
 		}
@@ -38,6 +47,92 @@ func TestRetrieveModFile(t *testing.T) {
 			t.Fatalf("expected error message %q, got %q", expectedErrMsg, err.Error())
 		}
 	})
+
+	t.Run("go.mod file at the root of a relative path", func(t *testing.T) {
+		fs := afero.NewMemMapFs()
+		err := afero.WriteFile(fs, "go.mod", []byte("module example.com/test"), 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		foundPath, err := retrieveModFile(fs, filepath.Join("nested", "dir"))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if foundPath != "go.mod" {
+			t.Fatalf("expected %q, got %q", "go.mod", foundPath)
+		}
+	})
+}
+
+func TestDetectGoMod(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	modFilePath := filepath.Join("project", "go.mod")
+	err := afero.WriteFile(fs, modFilePath, []byte("module example.com/test\n\ngo 1.22\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootDir, ver, err := detectGoMod(fs, filepath.Join("project", "pkg"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rootDir != "project" {
+		t.Fatalf("expected root dir %q, got %q", "project", rootDir)
+	}
+	if !ver.Equal(Go122) {
+		t.Fatalf("expected version %v, got %v", Go122, ver)
+	}
+}
+
+func TestDetectGoVersion(t *testing.T) {
+	t.Setenv("GOWORK", "")
+
+	fs := afero.NewMemMapFs()
+	for name, content := range map[string]string{
+		"toolchain/go.mod":     "module example.com/toolchain\n\ngo 1.22.3\n\ntoolchain go1.23.1\n",
+		"rc/go.mod":            "module example.com/rc\n\ngo 1.21rc1\n",
+		"nogo/go.mod":          "module example.com/nogo\n",
+		"work/go.work":         "go 1.23\n\nuse ./mod\n",
+		"work/mod/go.mod":      "module example.com/mod\n\ngo 1.21\n",
+		"work/mod/sub/go.mod":  "module example.com/mod/sub\n\ngo 1.22\n",
+		"work/scripts/main.go": "package main\n",
+	} {
+		if err := afero.WriteFile(fs, filepath.FromSlash(name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		dir      string
+		expected string
+	}{
+		{dir: "toolchain", expected: "1.22"},
+		{dir: "rc", expected: "1.21"},
+		{dir: "nogo", expected: "1.0"},
+		{dir: "work/mod/pkg", expected: "1.21"},
+		{dir: "work/mod/sub", expected: "1.22"},
+		{dir: "work/scripts", expected: "1.23"},
+		{dir: "nomod", expected: "1.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dir, func(t *testing.T) {
+			got := detectGoVersion(fs, filepath.FromSlash(tt.dir))
+			if got.Original() != tt.expected {
+				t.Errorf("expected version %v, got %v", tt.expected, got.Original())
+			}
+		})
+	}
+
+	t.Run("workspace mode disabled", func(t *testing.T) {
+		t.Setenv("GOWORK", "off")
+
+		got := detectGoVersion(fs, filepath.FromSlash("work/scripts"))
+		if !got.Equal(defaultGoVersion) {
+			t.Errorf("expected version %v, got %v", defaultGoVersion, got)
+		}
+	})
 }
 
 // TestIsGenerated tests isGenerated function.
@@ -67,3 +162,246 @@ func TestIsGenerated(t *testing.T) {
 		}
 	}
 }
+
+// typeOfVarsRule reports the type of the variables declared in a file.
+type typeOfVarsRule struct{}
+
+func (*typeOfVarsRule) Name() string { return "type-of-vars" }
+
+func (*typeOfVarsRule) Apply(file *File, _ Arguments) []Failure {
+	file.Pkg.TypeCheck()
+
+	var failures []Failure
+	ast.Inspect(file.AST, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok {
+			return true
+		}
+		for _, value := range spec.Values {
+			failures = append(failures, Failure{
+				Confidence: 1,
+				Node:       value,
+				Failure:    file.Pkg.TypeOf(value).String(),
+			})
+		}
+		return true
+	})
+	return failures
+}
+
+func TestLintPackagesTypeCheckFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go":      "package pkg\n\ntype T struct{}\n\nfunc New() T { return T{} }\n",
+		"a_test.go": "package pkg\n\nvar v = New()\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	l := New(os.ReadFile, 0)
+	failures, err := l.LintPackages(context.Background(), []PackageFiles{{
+		Files:          []string{filepath.Join(dir, "a_test.go")},
+		TypeCheckFiles: []string{filepath.Join(dir, "a.go")},
+	}}, []Rule{&typeOfVarsRule{}}, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for failure := range failures {
+		got = append(got, failure.Filename()+": "+failure.Failure)
+		if string(failure.FileContent) != files["a_test.go"] {
+			t.Errorf("got file content %q, want %q", failure.FileContent, files["a_test.go"])
+		}
+	}
+
+	want := filepath.Join(dir, "a_test.go") + ": pkg.T"
+	if len(got) != 1 || got[0] != want {
+		t.Fatalf("expected [%s], got %v", want, got)
+	}
+}
+
+// packageCountRule reports the number of linted packages on the package clause of every file.
+type packageCountRule struct{}
+
+func (*packageCountRule) Name() string { return "package-count" }
+
+func (*packageCountRule) Apply(*File, Arguments) []Failure { return nil }
+
+func (*packageCountRule) ApplyProgram(program *Program, _ Arguments) []Failure {
+	var failures []Failure
+	for _, pkg := range program.Packages() {
+		for _, file := range pkg.Files() {
+			failures = append(failures, Failure{
+				Failure:  fmt.Sprintf("one of %d packages", len(program.Packages())),
+				Position: ToFailurePosition(file.AST.Name.Pos(), file.AST.Name.End(), file),
+			})
+		}
+	}
+	return failures
+}
+
+func TestLintPackagesProgramRule(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go": "package a\n",
+		"b.go": "//revive:disable:package-count\npackage b\n",
+		"c.go": "package c\n",
+	}
+	var packages []PackageFiles
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		packages = append(packages, PackageFiles{Files: []string{filepath.Join(dir, name)}})
+	}
+
+	ruleConfig := RuleConfig{Exclude: []string{`~c\.go$`}}
+	if err := ruleConfig.Initialize(); err != nil {
+		t.Fatal(err)
+	}
+
+	l := New(os.ReadFile, 0)
+	failures, err := l.LintPackages(context.Background(), packages, []Rule{&packageCountRule{}}, Config{
+		Rules: RulesConfig{"package-count": ruleConfig},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for failure := range failures {
+		got = append(got, failure.Filename()+": "+failure.RuleName+": "+failure.Failure)
+	}
+
+	want := filepath.Join(dir, "a.go") + ": package-count: one of 3 packages"
+	if len(got) != 1 || got[0] != want {
+		t.Fatalf("expected [%s], got %v", want, got)
+	}
+}
+
+// crossPackageRule reports the uses of the functions of the program, with the package defining them.
+type crossPackageRule struct{}
+
+func (*crossPackageRule) Name() string { return "cross-package" }
+
+func (*crossPackageRule) Apply(*File, Arguments) []Failure { return nil }
+
+func (*crossPackageRule) ApplyProgram(program *Program, _ Arguments) []Failure {
+	definedIn := map[types.Object]*Package{}
+	for _, pkg := range program.Packages() {
+		for _, obj := range program.TypesInfo(pkg).Defs {
+			if _, ok := obj.(*types.Func); ok {
+				definedIn[obj] = pkg
+			}
+		}
+	}
+
+	var failures []Failure
+	for _, pkg := range program.Packages() {
+		for id, obj := range program.TypesInfo(pkg).Uses {
+			if definer, ok := definedIn[obj]; ok && definer != pkg {
+				file := program.File(pkg.fset.Position(id.Pos()).Filename)
+				failures = append(failures, Failure{
+					Failure:  id.Name + " of " + program.TypesPkg(definer).Path(),
+					Position: ToFailurePosition(id.Pos(), id.End(), file),
+				})
+			}
+		}
+	}
+	return failures
+}
+
+func TestLintPackagesProgramTypes(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":   "module example.com/m\n\ngo 1.22\n",
+		"a/a.go":   "package a\n\nfunc F() {}\n",
+		"b/b.go":   "package b\n\nimport \"example.com/m/a\"\n\nfunc G() { a.F() }\n",
+		"c/c.go":   "package c\n\nimport \"example.com/m/b\"\n\nvar _ = b.G\n",
+		"b/b_x.go": "package b\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var packages []PackageFiles
+	for _, pkg := range [][]string{{"c/c.go"}, {"b/b.go", "b/b_x.go"}, {"a/a.go"}} {
+		var pkgFiles PackageFiles
+		for _, name := range pkg {
+			pkgFiles.Files = append(pkgFiles.Files, filepath.Join(dir, filepath.FromSlash(name)))
+		}
+		packages = append(packages, pkgFiles)
+	}
+
+	l := New(os.ReadFile, 0)
+	failures, err := l.LintPackages(context.Background(), packages, []Rule{&crossPackageRule{}}, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for failure := range failures {
+		got = append(got, filepath.ToSlash(strings.TrimPrefix(failure.Filename(), dir))+": "+failure.Failure)
+	}
+	slices.Sort(got)
+
+	// the objects used by the packages are the ones defined by the packages of the program
+	want := []string{"/b/b.go: F of example.com/m/a", "/c/c.go: G of example.com/m/b"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+// cancelingRule cancels the run on its first application, counting its applications.
+type cancelingRule struct {
+	cancel  context.CancelFunc
+	applied atomic.Int32
+}
+
+func (*cancelingRule) Name() string { return "canceling" }
+
+func (r *cancelingRule) Apply(*File, Arguments) []Failure {
+	r.applied.Add(1)
+	r.cancel()
+	return nil
+}
+
+func TestLintPackagesCanceled(t *testing.T) {
+	dir := t.TempDir()
+	var packages []PackageFiles
+	for i := range 50 {
+		filename := filepath.Join(dir, fmt.Sprintf("p%d", i), "a.go")
+		if err := os.MkdirAll(filepath.Dir(filename), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filename, []byte("package p\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		packages = append(packages, PackageFiles{Files: []string{filename}})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rule := &cancelingRule{cancel: cancel}
+	l := New(os.ReadFile, 1)
+	failures, err := l.LintPackages(ctx, packages, []Rule{rule}, Config{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for failure := range failures {
+		t.Errorf("unexpected failure %q", failure.Failure)
+	}
+
+	if got := rule.applied.Load(); got >= int32(len(packages)) {
+		t.Errorf("the rule was applied to %d packages once the run was canceled, want fewer than %d", got, len(packages))
+	}
+}
@@ -1,11 +1,13 @@
 package lint
 
 import (
+	"context"
 	"errors"
 	"go/ast"
 	"go/importer"
 	"go/token"
 	"go/types"
+	"slices"
 	"sync"
 
 	goversion "github.com/hashicorp/go-version"
@@ -28,6 +30,8 @@ type Package struct {
 	sortable map[string]bool
 	// main is whether this is a "main" package.
 	main int
+	// typeCheckFiles are type-checked along with files but not linted.
+	typeCheckFiles []*ast.File
 }
 
 var (
@@ -36,6 +40,8 @@ var (
 
 	// Go115 is a constant representing the Go version 1.15.
 	Go115 = goversion.Must(goversion.NewVersion("1.15"))
+	// Go118 is a constant representing the Go version 1.18.
+	Go118 = goversion.Must(goversion.NewVersion("1.18"))
 	// Go121 is a constant representing the Go version 1.21.
 	Go121 = goversion.Must(goversion.NewVersion("1.21"))
 	// Go122 is a constant representing the Go version 1.22.
@@ -112,18 +118,14 @@ func (p *Package) TypeCheck() error {
 		Error:    func(error) {},
 		Importer: importer.Default(),
 	}
-	info := &types.Info{
-		Types:  map[ast.Expr]types.TypeAndValue{},
-		Defs:   map[*ast.Ident]types.Object{},
-		Uses:   map[*ast.Ident]types.Object{},
-		Scopes: map[ast.Node]*types.Scope{},
-	}
+	info := newTypesInfo()
 	var anyFile *File
 	var astFiles []*ast.File
 	for _, f := range p.files {
 		anyFile = f
 		astFiles = append(astFiles, f.AST)
 	}
+	astFiles = append(astFiles, p.typeCheckFiles...)
 
 	if anyFile == nil {
 		// this is unlikely to happen, but technically guarantees anyFile to not be nil
@@ -140,6 +142,16 @@ func (p *Package) TypeCheck() error {
 	return err
 }
 
+// newTypesInfo returns the type information recorded when type-checking a package.
+func newTypesInfo() *types.Info {
+	return &types.Info{
+		Types:  map[ast.Expr]types.TypeAndValue{},
+		Defs:   map[*ast.Ident]types.Object{},
+		Uses:   map[*ast.Ident]types.Object{},
+		Scopes: map[ast.Node]*types.Scope{},
+	}
+}
+
 // check function encapsulates the call to go/types.Config.Check method and
 // recovers if the called method panics (see issue #59).
 func check(config *types.Config, n string, fset *token.FileSet, astFiles []*ast.File, info *types.Info) (p *types.Package, err error) {
@@ -179,9 +191,14 @@ func (p *Package) scanSortable() {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 
-	sortableFlags := map[string]sortableMethodsFlags{}
+	astFiles := slices.Clone(p.typeCheckFiles)
 	for _, f := range p.files {
-		for _, decl := range f.AST.Decls {
+		astFiles = append(astFiles, f.AST)
+	}
+
+	sortableFlags := map[string]sortableMethodsFlags{}
+	for _, astFile := range astFiles {
+		for _, decl := range astFile.Decls {
 			fn, ok := decl.(*ast.FuncDecl)
 			isAMethodDeclaration := ok && fn.Recv != nil && len(fn.Recv.List) != 0
 			if !isAMethodDeclaration {
@@ -201,19 +218,89 @@ func (p *Package) scanSortable() {
 	}
 }
 
-func (p *Package) lint(rules []Rule, config Config, failures chan Failure) error {
+func (p *Package) lint(ctx context.Context, rules []Rule, config Config, failures chan Failure) error {
 	p.scanSortable()
 	var eg errgroup.Group
 	for _, file := range p.Files() {
 		eg.Go(func() error {
-			return file.lint(rules, config, failures)
+			return file.lint(ctx, rules, config, failures)
 		})
 	}
+	if err := eg.Wait(); err != nil {
+		return err
+	}
+
+	// package rules run once the comment directives of all the files are known
+	for _, rule := range rules {
+		packageRule, ok := rule.(PackageRule)
+		if !ok {
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		ruleFailures := packageRule.ApplyPackage(p, config.Rules[rule.Name()].Arguments)
+		for i := range ruleFailures {
+			ruleFailures[i].setNodePositions(p.fset)
+		}
+
+		fileOf := func(name string) *File { return p.Files()[name] }
+		if err := sendRuleFailures(ctx, rule, ruleFailures, fileOf, config, failures); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sendRuleFailures sends the failures of a package or a program rule, filtered
+// like the ones of file rules by exclusions, comment directives and confidence.
+func sendRuleFailures(ctx context.Context, rule Rule, ruleFailures []Failure, fileOf func(name string) *File, config Config, failures chan Failure) error {
+	ruleConfig := config.Rules[rule.Name()]
+	for _, failure := range ruleFailures {
+		if failure.IsInternal() {
+			return errors.New(failure.Failure)
+		}
+		if failure.RuleName == "" {
+			failure.RuleName = rule.Name()
+		}
+		if failure.Confidence < config.Confidence {
+			continue
+		}
+
+		filename := failure.Position.Start.Filename
+		if ruleConfig.MustExclude(filename) {
+			continue
+		}
+		file := fileOf(filename)
+		if file != nil {
+			if file.generated && !ruleConfig.LintsGenerated() {
+				continue
+			}
+			if len(file.filterFailures([]Failure{failure}, file.disabled)) == 0 {
+				continue
+			}
+			if failure.FileContent == nil {
+				failure.FileContent = file.content
+			}
+		}
+		if config.isExcluded(failure, file) {
+			continue
+		}
+
+		if err := sendFailure(ctx, failures, failure); err != nil {
+			return err
+		}
+	}
 
-	return eg.Wait()
+	return nil
 }
 
 // IsAtLeastGoVersion returns true if the Go version for this package is v or higher, false otherwise.
+//
+// Deprecated: a //go:build constraint can change the Go version of a file;
+// use [File.IsAtLeastGoVersion] instead.
 func (p *Package) IsAtLeastGoVersion(v *goversion.Version) bool {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -0,0 +1,148 @@
+package lint
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/types"
+	"maps"
+	"slices"
+	"sync"
+)
+
+// Program represents all the packages of a linting run.
+type Program struct {
+	packages []*Package
+	// files are the files of the packages, by name
+	files map[string]*File
+	// importPaths are the import paths of the packages the other ones can import
+	importPaths map[*Package]string
+
+	typeCheckOnce sync.Once
+	typesPkgs     map[*Package]*types.Package
+	typesInfos    map[*Package]*types.Info
+}
+
+func newProgram(packages []*Package, importPaths map[*Package]string) *Program {
+	files := map[string]*File{}
+	for _, pkg := range packages {
+		maps.Copy(files, pkg.Files())
+	}
+
+	return &Program{
+		packages:    packages,
+		files:       files,
+		importPaths: importPaths,
+	}
+}
+
+// Packages returns the packages of the program, ordered by the names of their files.
+//
+// The type information of a package, given by [Package.TypesInfo], comes from the package type-checked on its own;
+// the one given by [Program.TypesInfo] comes from the program type-checked as a whole.
+func (p *Program) Packages() []*Package {
+	return p.packages
+}
+
+// File returns the file of the program with the given name, or nil if there is none.
+func (p *Program) File(name string) *File {
+	return p.files[name]
+}
+
+// TypesPkg returns the types of the package, type-checked with the other packages of the program:
+// the packages it imports from the program are the ones of the program, not the ones of their export data.
+// It returns nil if the package is not part of the program.
+func (p *Program) TypesPkg(pkg *Package) *types.Package {
+	p.typeCheckOnce.Do(p.typeCheck)
+	return p.typesPkgs[pkg]
+}
+
+// TypesInfo returns the type information of the package, type-checked with the other packages of the program:
+// the objects it uses from the packages of the program are the ones these packages define.
+// It returns nil if the package is not part of the program.
+func (p *Program) TypesInfo(pkg *Package) *types.Info {
+	p.typeCheckOnce.Do(p.typeCheck)
+	return p.typesInfos[pkg]
+}
+
+// typeCheck type-checks the packages of the program, each one after the packages it imports from the program.
+func (p *Program) typeCheck() {
+	p.typesPkgs = map[*Package]*types.Package{}
+	p.typesInfos = map[*Package]*types.Info{}
+
+	imp := &programImporter{
+		program:  p,
+		packages: map[string]*Package{},
+		checking: map[*Package]bool{},
+		// the packages outside the program are shared by the packages of the program too
+		fallback: importer.Default(),
+	}
+	for pkg, importPath := range p.importPaths {
+		imp.packages[importPath] = pkg
+	}
+	for _, pkg := range p.packages {
+		imp.check(pkg)
+	}
+}
+
+// programImporter imports the packages of a program from their files, and the other ones from their export data.
+type programImporter struct {
+	program *Program
+	// packages are the packages of the program, by import path
+	packages map[string]*Package
+	// checking are the packages being type-checked, to detect import cycles
+	checking map[*Package]bool
+	fallback types.Importer
+}
+
+func (pi *programImporter) Import(path string) (*types.Package, error) {
+	pkg, ok := pi.packages[path]
+	if !ok {
+		return pi.fallback.Import(path)
+	}
+	if typesPkg := pi.check(pkg); typesPkg != nil {
+		return typesPkg, nil
+	}
+	return nil, fmt.Errorf("import cycle through %s", path)
+}
+
+// check type-checks the package unless it already is, and returns its types, nil if it is part of an import cycle.
+func (pi *programImporter) check(pkg *Package) *types.Package {
+	if typesPkg, ok := pi.program.typesPkgs[pkg]; ok {
+		return typesPkg
+	}
+	if pi.checking[pkg] {
+		return nil
+	}
+	pi.checking[pkg] = true
+	defer delete(pi.checking, pkg)
+
+	files := pkg.Files()
+	names := slices.Sorted(maps.Keys(files))
+	astFiles := make([]*ast.File, 0, len(names)+len(pkg.typeCheckFiles))
+	for _, name := range names {
+		astFiles = append(astFiles, files[name].AST)
+	}
+	astFiles = append(astFiles, pkg.typeCheckFiles...)
+
+	path := pi.program.importPaths[pkg]
+	if path == "" {
+		path = astFiles[0].Name.Name
+	}
+
+	config := &types.Config{
+		// By setting a no-op error reporter, the type checker does as much work as possible.
+		Error:    func(error) {},
+		Importer: pi,
+	}
+	info := newTypesInfo()
+	// the type information is kept even if the check failed, since it is partial
+	typesPkg, _ := check(config, path, pkg.fset, astFiles, info)
+	if typesPkg == nil {
+		typesPkg = types.NewPackage(path, astFiles[0].Name.Name)
+	}
+
+	pi.program.typesPkgs[pkg] = typesPkg
+	pi.program.typesInfos[pkg] = info
+	return typesPkg
+}
@@ -17,6 +17,46 @@ type Rule interface {
 	Apply(*File, Arguments) []Failure
 }
 
+// PackageRule defines an abstract interface for rules applied to all the files of a package at once.
+//
+// The linter calls ApplyPackage, instead of Apply, once per package.
+type PackageRule interface {
+	Rule
+	ApplyPackage(*Package, Arguments) []Failure
+}
+
+// ProgramRule defines an abstract interface for rules applied to all the linted packages at once.
+//
+// The linter calls ApplyProgram, instead of Apply, once all the packages are linted.
+// As each package has its own file set, the failures must have their position set,
+// for example with [ToFailurePosition].
+// [Program.TypesInfo] gives the type information of the packages type-checked as a whole,
+// for the rules to relate the uses of the objects of a package to their definitions in another one.
+type ProgramRule interface {
+	Rule
+	ApplyProgram(*Program, Arguments) []Failure
+}
+
+// isFileRule returns true if the linter applies the rule file by file.
+func isFileRule(rule Rule) bool {
+	switch rule.(type) {
+	case PackageRule, ProgramRule:
+		return false
+	default:
+		return true
+	}
+}
+
+// ActualRuleName returns the name of the rule formerly named name, or name itself if the rule was not renamed.
+func ActualRuleName(name string) string {
+	switch name {
+	case "imports-blacklist":
+		return "imports-blocklist"
+	default:
+		return name
+	}
+}
+
 // ConfigurableRule defines an abstract configurable rule interface.
 type ConfigurableRule interface {
 	Configure(Arguments) error
@@ -2,14 +2,19 @@
 package revivelib
 
 import (
+	"cmp"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"maps"
-	"os"
 	"slices"
 	"strings"
+	"sync"
+	"time"
 
-	"github.com/mgechev/dots"
+	"github.com/spf13/afero"
 
 	"github.com/mgechev/revive/config"
 	"github.com/mgechev/revive/lint"
@@ -23,27 +28,99 @@ type Revive struct {
 	lintingRules []lint.Rule
 	logger       *slog.Logger
 	maxOpenFiles int
+	fs           afero.Fs
+	buildTags    []string
+	goos         string
+	goarch       string
+	onFile       func(lint.FileReport)
+	sortOrder    SortOrder
+	codeOwners   *lint.CodeOwners
+	owners       []string
+	gitIgnore    bool
+	cache        *lint.Cache
+	warnings     io.Writer
+	// discovered is the CODEOWNERS file of the repository, if no CODEOWNERS file is set
+	discovered *discoveredCodeOwners
+	// uncached are the files whose packages are linted without the cache
+	uncached []string
+}
+
+// LintRun is a linting run started by [Revive.StartLint].
+type LintRun struct {
+	// Failures receives the failures of the run, and is closed once the run completes or its context is done.
+	Failures <-chan lint.Failure
+	files    *runFiles
+}
+
+// runFiles records the files handled during a run.
+type runFiles struct {
+	mu      sync.Mutex
+	reports []lint.FileReport
+}
+
+func (rf *runFiles) add(report lint.FileReport) {
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	rf.reports = append(rf.reports, report)
+}
+
+// sorted returns the reports sorted by file name, none if rf is nil.
+func (rf *runFiles) sorted() []lint.FileReport {
+	if rf == nil {
+		return nil
+	}
+	rf.mu.Lock()
+	defer rf.mu.Unlock()
+	return slices.SortedFunc(slices.Values(rf.reports), func(a, b lint.FileReport) int {
+		return strings.Compare(a.Name, b.Name)
+	})
 }
 
 // New creates a new instance of Revive lint runner.
-func New(
-	conf *lint.Config,
-	setExitStatus bool,
-	maxOpenFiles int,
-	extraRules ...ExtraRule,
-) (*Revive, error) {
+func New(conf *lint.Config, opts ...Option) (*Revive, error) {
 	logger, err := logging.GetLogger()
 	if err != nil {
 		return nil, fmt.Errorf("initializing revive - getting logger: %w", err)
 	}
 
-	if setExitStatus {
+	o := options{
+		fs:        afero.NewOsFs(),
+		warnings:  io.Discard,
+		sortOrder: SortByPosition,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if !slices.Contains(SortOrders, o.sortOrder) {
+		return nil, fmt.Errorf("initializing revive - unknown sort order %q", o.sortOrder)
+	}
+
+	// the CODEOWNERS file of the repository is only read when asked for, or once a formatter writes the owners
+	var codeOwners *lint.CodeOwners
+	var discovered *discoveredCodeOwners
+	codeOwnersPath := cmp.Or(o.codeOwners, conf.CodeOwners)
+	if codeOwnersPath == "" && len(o.owners) > 0 {
+		codeOwnersPath = FindCodeOwners(o.fs)
+		if codeOwnersPath == "" {
+			return nil, errors.New("initializing revive - filtering by owner needs a CODEOWNERS file")
+		}
+	}
+	if codeOwnersPath != "" {
+		if codeOwners, err = loadCodeOwners(o.fs, codeOwnersPath); err != nil {
+			return nil, fmt.Errorf("initializing revive - reading the CODEOWNERS file: %w", err)
+		}
+	} else {
+		discovered = &discoveredCodeOwners{}
+	}
+
+	if o.setExitStatus {
 		conf.ErrorCode = 1
 		conf.WarningCode = 1
 	}
 
-	extraRuleInstances := make([]lint.Rule, len(extraRules))
-	for i, extraRule := range extraRules {
+	extraRuleInstances := make([]lint.Rule, len(o.extraRules))
+	for i, extraRule := range o.extraRules {
 		extraRuleInstances[i] = extraRule.Rule
 
 		ruleName := extraRule.Rule.Name()
@@ -65,12 +142,98 @@ func New(
 		logger:       logger,
 		config:       conf,
 		lintingRules: lintingRules,
-		maxOpenFiles: maxOpenFiles,
+		maxOpenFiles: o.maxOpenFiles,
+		fs:           o.fs,
+		buildTags:    o.buildTags,
+		goos:         o.goos,
+		goarch:       o.goarch,
+		onFile:       o.onFile,
+		sortOrder:    o.sortOrder,
+		codeOwners:   codeOwners,
+		discovered:   discovered,
+		owners:       o.owners,
+		warnings:     o.warnings,
+		gitIgnore:    o.gitIgnore,
+		cache:        o.cache,
 	}, nil
 }
 
 // Lint the included patterns, skipping excluded ones.
+// The files ignored by the .reviveignore files of the repository are skipped too, see [WithGitIgnore].
 func (r *Revive) Lint(patterns ...*LintPattern) (<-chan lint.Failure, error) {
+	return r.LintContext(context.Background(), patterns...)
+}
+
+// LintContext lints the included patterns, skipping excluded ones.
+// Linting stops when the context is done; the failures channel is then closed.
+func (r *Revive) LintContext(ctx context.Context, patterns ...*LintPattern) (<-chan lint.Failure, error) {
+	return r.lint(ctx, *r.config, nil, patterns...)
+}
+
+// StartLint starts linting the included patterns, skipping excluded ones, like LintContext.
+// The run also records the files it handles, for [Revive.FormatRun] to give them to the formatters.
+func (r *Revive) StartLint(ctx context.Context, patterns ...*LintPattern) (*LintRun, error) {
+	files := &runFiles{}
+	failures, err := r.lint(ctx, *r.config, files.add, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LintRun{Failures: failures, files: files}, nil
+}
+
+// Run lints the included patterns, skipping excluded ones, and waits for the linting to complete.
+func (r *Revive) Run(ctx context.Context, patterns ...*LintPattern) (*Result, error) {
+	result := &Result{
+		Counts:    map[lint.Severity]int{},
+		StartTime: time.Now(),
+	}
+
+	var mu sync.Mutex
+	onFile := func(report lint.FileReport) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case report.Generated:
+			result.GeneratedFiles = append(result.GeneratedFiles, report.Name)
+		case report.Invalid:
+			result.SkippedFiles = append(result.SkippedFiles, report.Name)
+		default:
+			result.LintedFiles = append(result.LintedFiles, report.Name)
+		}
+	}
+
+	// the run counts the failures excluded by the [[exclude-rule]] entries on its own
+	conf := *r.config
+	conf.ExcludeRules = lint.CloneExcludeRules(conf.ExcludeRules)
+	result.ExcludeRules = conf.ExcludeRules
+
+	failures, err := r.lint(ctx, conf, onFile, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	for failure := range r.ownedFailures(r.codeOwners, r.orderedFailures(failures)) {
+		if failure.Confidence < r.config.Confidence {
+			continue
+		}
+
+		result.Failures = append(result.Failures, failure)
+		result.Counts[r.severity(failure)]++
+		result.ExitCode = r.exitCode(result.ExitCode, failure)
+	}
+
+	result.Duration = time.Since(result.StartTime)
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("linting: %w", err)
+	}
+
+	return result, nil
+}
+
+func (r *Revive) lint(ctx context.Context, conf lint.Config, onFile func(lint.FileReport), patterns ...*LintPattern) (<-chan lint.Failure, error) {
 	includePatterns := []string{}
 	excludePatterns := []string{}
 
@@ -83,7 +246,7 @@ func (r *Revive) Lint(patterns ...*LintPattern) (<-chan lint.Failure, error) {
 	}
 
 	if len(excludePatterns) == 0 { // if no excludes were set
-		excludePatterns = r.config.Exclude // use those from the configuration
+		excludePatterns = conf.Exclude // use those from the configuration
 	}
 
 	// by default if no excludes exclude vendor
@@ -91,21 +254,37 @@ func (r *Revive) Lint(patterns ...*LintPattern) (<-chan lint.Failure, error) {
 		excludePatterns = []string{"vendor/..."}
 	}
 
-	packages, err := getPackages(includePatterns, excludePatterns)
+	packages, err := r.getPackages(includePatterns, excludePatterns)
 	if err != nil {
 		return nil, fmt.Errorf("linting - getting packages: %w", err)
 	}
 
 	revive := lint.New(func(file string) ([]byte, error) {
-		contents, err := os.ReadFile(file)
+		contents, err := afero.ReadFile(r.fs, file)
 		if err != nil {
 			return nil, fmt.Errorf("reading file %v: %w", file, err)
 		}
 
 		return contents, nil
 	}, r.maxOpenFiles)
+	revive.OnFile(func(report lint.FileReport) {
+		r.logger.Info("File handled", "file", report.Name, "goVersion", report.GoVersion, "generated", report.Generated, "generatedLinted", report.GeneratedLinted, "invalid", report.Invalid)
+		if r.onFile != nil {
+			r.onFile(report)
+		}
+		if onFile != nil {
+			onFile(report)
+		}
+	})
+	if _, isOsFs := r.fs.(*afero.OsFs); !isOsFs {
+		revive.UseFileSystem(r.fs)
+	}
+	if r.cache != nil {
+		revive.UseCache(r.cache)
+		revive.BypassCache(r.uncached...)
+	}
 
-	failures, err := revive.Lint(packages, r.lintingRules, *r.config)
+	failures, err := revive.LintPackages(ctx, packages, r.lintingRules, conf)
 	if err != nil {
 		return nil, fmt.Errorf("linting - retrieving failures channel: %w", err)
 	}
@@ -118,65 +297,140 @@ func (r *Revive) Format(
 	formatterName string,
 	failuresChan <-chan lint.Failure,
 ) (output string, exitCode int, err error) {
-	conf := r.config
-	formatChan := make(chan lint.Failure)
-	exitChan := make(chan bool)
-
-	formatter, err := config.GetFormatter(formatterName)
+	var sb strings.Builder
+	exitCode, err = r.FormatTo(&sb, formatterName, failuresChan)
 	if err != nil {
-		return "", 0, fmt.Errorf("formatting - getting formatter: %w", err)
+		return "", exitCode, err
 	}
 
-	var (
-		out       string
-		formatErr error
-	)
+	return sb.String(), exitCode, nil
+}
+
+// FormatTo writes the failures of a channel from Lint to w, in the sort order of revive,
+// and returns the exit code of the run.
+//
+// The channel is read until it is closed, even if writing the failures fails.
+func (r *Revive) FormatTo(w io.Writer, formatterName string, failuresChan <-chan lint.Failure) (exitCode int, err error) {
+	return r.FormatOutputs([]Output{{Formatter: formatterName, Writer: w}}, failuresChan)
+}
+
+// FormatOutputs writes the failures of a channel from Lint to several outputs, in the sort order of revive,
+// and returns the exit code of the run. The failures are written as they arrive with [SortNone].
+//
+// The channel is read until it is closed, even if writing the failures to an output fails.
+// The formatters implementing [lint.FileWriter] get no file; see [Revive.FormatRun].
+func (r *Revive) FormatOutputs(outputs []Output, failuresChan <-chan lint.Failure) (exitCode int, err error) {
+	return r.formatOutputs(outputs, failuresChan, nil)
+}
 
-	go func() {
-		out, formatErr = formatter.Format(formatChan, *conf)
+// FormatRun writes the failures of a run to several outputs like FormatOutputs, then gives the files
+// handled by the run to the formatters implementing [lint.FileWriter].
+func (r *Revive) FormatRun(outputs []Output, run *LintRun) (exitCode int, err error) {
+	return r.formatOutputs(outputs, run.Failures, run.files)
+}
 
-		exitChan <- true
-	}()
+func (r *Revive) formatOutputs(outputs []Output, failuresChan <-chan lint.Failure, runFiles *runFiles) (exitCode int, err error) {
+	conf := r.config
 
-	for failure := range failuresChan {
+	formatters := make([]lint.StreamFormatter, len(outputs))
+	writesOwners := false
+	for i, output := range outputs {
+		formatter, err := config.GetFormatter(output.Formatter)
+		if err != nil {
+			return 0, fmt.Errorf("formatting - getting formatter: %w", err)
+		}
+		if ownersFormatter, ok := formatter.(lint.OwnersFormatter); ok && ownersFormatter.WritesOwners() {
+			writesOwners = true
+		}
+		formatters[i] = lint.AsStreamFormatter(formatter)
+	}
+
+	writers := make([]lint.FailureWriter, len(outputs))
+	formatErrs := make([]error, len(outputs))
+	for i, output := range outputs {
+		writers[i], formatErrs[i] = formatters[i].Begin(output.Writer, *conf)
+	}
+
+	for failure := range r.ownedFailures(r.codeOwnersFor(writesOwners), r.orderedFailures(failuresChan)) {
 		if failure.Confidence < conf.Confidence {
 			continue
 		}
 
-		if exitCode == 0 {
-			exitCode = conf.WarningCode
-		}
+		exitCode = r.exitCode(exitCode, failure)
 
-		if c, ok := conf.Rules[failure.RuleName]; ok && c.Severity == lint.SeverityError {
-			exitCode = conf.ErrorCode
+		for i, fw := range writers {
+			if formatErrs[i] == nil {
+				formatErrs[i] = fw.Failure(failure)
+			}
 		}
+	}
 
-		if c, ok := conf.Directives[failure.RuleName]; ok && c.Severity == lint.SeverityError {
-			exitCode = conf.ErrorCode
+	files := r.ownedFiles(runFiles.sorted())
+	for i, fw := range writers {
+		if fileWriter, ok := fw.(lint.FileWriter); ok {
+			for _, file := range files {
+				if formatErrs[i] != nil {
+					break
+				}
+				formatErrs[i] = fileWriter.File(file)
+			}
+		}
+		if formatErrs[i] == nil {
+			formatErrs[i] = fw.End()
 		}
+		if formatErrs[i] != nil {
+			formatErrs[i] = fmt.Errorf("formatting with %s: %w", formatters[i].Name(), formatErrs[i])
+		}
+	}
 
-		formatChan <- failure
+	if err := errors.Join(formatErrs...); err != nil {
+		return exitCode, err
 	}
 
-	close(formatChan)
-	<-exitChan
+	return exitCode, nil
+}
+
+// severity returns the severity configured for the rule that raised the failure.
+func (r *Revive) severity(failure lint.Failure) lint.Severity {
+	if c, ok := r.config.Rules[failure.RuleName]; ok && c.Severity == lint.SeverityError {
+		return lint.SeverityError
+	}
+
+	if c, ok := r.config.Directives[failure.RuleName]; ok && c.Severity == lint.SeverityError {
+		return lint.SeverityError
+	}
 
-	if formatErr != nil {
-		return "", exitCode, fmt.Errorf("formatting: %w", formatErr)
+	return lint.SeverityWarning
+}
+
+// exitCode returns the exit code of the run once the failure is reported,
+// given the exit code before it.
+func (r *Revive) exitCode(current int, failure lint.Failure) int {
+	if r.severity(failure) == lint.SeverityError {
+		return r.config.ErrorCode
 	}
 
-	return out, exitCode, nil
+	if current == 0 {
+		return r.config.WarningCode
+	}
+
+	return current
 }
 
-func getPackages(includePatterns []string, excludePatterns ArrayFlags) ([][]string, error) {
+func (r *Revive) getPackages(includePatterns []string, excludePatterns ArrayFlags) ([]lint.PackageFiles, error) {
 	globs := normalizeSplit(includePatterns)
 	if len(globs) == 0 {
 		globs = append(globs, ".")
 	}
 
-	packages, err := dots.ResolvePackages(globs, normalizeSplit(excludePatterns))
+	resolver := newPackageResolver(r.fs)
+	resolver.setPlatform(r.goos, r.goarch)
+	resolver.ctx.BuildTags = r.buildTags
+	resolver.ignore = newIgnoreMatcher(r.fs, r.gitIgnore)
+
+	packages, err := resolver.resolvePackages(globs, normalizeSplit(excludePatterns))
 	if err != nil {
-		return nil, fmt.Errorf("getting packages - resolving packages in dots: %w", err)
+		return nil, fmt.Errorf("getting packages - resolving packages: %w", err)
 	}
 
 	return packages, nil
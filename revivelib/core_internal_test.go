@@ -57,9 +57,9 @@ func getMockRevive(t *testing.T) *Revive {
 
 	revive, err := New(
 		conf,
-		true,
-		2048,
-		NewExtraRule(&mockRule{}, lint.RuleConfig{}),
+		WithSetExitStatus(true),
+		WithMaxOpenFiles(2048),
+		WithExtraRules(NewExtraRule(&mockRule{}, lint.RuleConfig{})),
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -1,10 +1,17 @@
 package revivelib_test
 
 import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"testing/fstest"
 
 	"github.com/fatih/color"
+	"github.com/spf13/afero"
 
 	"github.com/mgechev/revive/config"
 	"github.com/mgechev/revive/lint"
@@ -73,6 +80,424 @@ func TestReviveFormat(t *testing.T) {
 	}
 }
 
+func TestReviveFormatTo(t *testing.T) {
+	revive := getMockRevive(t)
+
+	failuresChan, err := revive.Lint(revivelib.Include("../testdata/if_return.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf strings.Builder
+	exitCode, err := revive.FormatTo(&buf, "unix", failuresChan)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	const expectedLines = 5
+	if len(lines) != expectedLines {
+		t.Fatalf("Expected %d lines, got %d:\n%s", expectedLines, len(lines), buf.String())
+	}
+	if !slices.Contains(lines, "../testdata/if_return.go:91:3: [unreachable-code] unreachable code after this statement") {
+		t.Errorf("Expected the output to contain the unreachable code failure, got:\n%s", buf.String())
+	}
+
+	const expected = 1
+	if exitCode != expected {
+		t.Fatalf("Expected exit code to be %d, but it was %d.", expected, exitCode)
+	}
+}
+
+func TestReviveSortOrder(t *testing.T) {
+	const (
+		return15      = "../testdata/if_return.go:15:2: [if-return] redundant if ...; err != nil check, just return error instead."
+		return88      = "../testdata/if_return.go:88:3: [if-return] redundant if ...; err != nil check, just return error instead."
+		unreachable91 = "../testdata/if_return.go:91:3: [unreachable-code] unreachable code after this statement"
+		return95      = "../testdata/if_return.go:95:3: [if-return] redundant if ...; err != nil check, just return error instead."
+		unreachable98 = "../testdata/if_return.go:98:3: [unreachable-code] unreachable code after this statement"
+	)
+	tests := []struct {
+		order revivelib.SortOrder
+		want  []string
+	}{
+		{order: revivelib.SortByPosition, want: []string{return15, return88, unreachable91, return95, unreachable98}},
+		{order: revivelib.SortByRule, want: []string{return15, return88, return95, unreachable91, unreachable98}},
+		{order: revivelib.SortBySeverity, want: []string{unreachable91, unreachable98, return15, return88, return95}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.order), func(t *testing.T) {
+			conf, err := config.GetConfig("../defaults.toml")
+			if err != nil {
+				t.Fatal(err)
+			}
+			conf.Rules["unreachable-code"] = lint.RuleConfig{Severity: lint.SeverityError}
+			revive, err := revivelib.New(
+				conf,
+				revivelib.WithSortOrder(tt.order),
+				revivelib.WithExtraRules(revivelib.NewExtraRule(&rule.IfReturnRule{}, lint.RuleConfig{})),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			failuresChan, err := revive.Lint(revivelib.Include("../testdata/if_return.go"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var buf strings.Builder
+			if _, err := revive.FormatTo(&buf, "unix", failuresChan); err != nil {
+				t.Fatal(err)
+			}
+
+			got := strings.Split(strings.TrimSpace(buf.String()), "\n")
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got failures\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestReviveUnknownSortOrder(t *testing.T) {
+	conf, err := config.GetConfig("../defaults.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := revivelib.New(conf, revivelib.WithSortOrder("random")); err == nil {
+		t.Fatal("Expected an error for an unknown sort order.")
+	}
+}
+
+func TestReviveFormatRunFiles(t *testing.T) {
+	revive := getMockRevive(t)
+
+	run, err := revive.StartLint(context.Background(), revivelib.Include("../testdata/if_return.go"), revivelib.Include("../testdata/golint/sort.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf strings.Builder
+	_, err = revive.FormatRun([]revivelib.Output{{Formatter: "junit", Writer: &buf}}, run)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the rules without failures in a file are reported by the formatters getting the files
+	const passed = `<testcase name="unreachable-code" classname="../testdata/golint/sort" file="../testdata/golint/sort.go"></testcase>`
+	if !strings.Contains(buf.String(), passed) {
+		t.Errorf("Expected the JUnit report to contain a passed test case, got:\n%s", buf.String())
+	}
+}
+
+func TestReviveRun(t *testing.T) {
+	// ARRANGE
+	revive := getMockRevive(t)
+
+	// ACT
+	result, err := revive.Run(context.Background(), revivelib.Include("../testdata/if_return.go"))
+	// ASSERT
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = 5
+	if got := len(result.Failures); got != expected {
+		t.Fatalf("Expected result to have %d failures, but it has %d.", expected, got)
+	}
+
+	if got := result.Counts[lint.SeverityWarning]; got != expected {
+		t.Fatalf("Expected result to have %d warnings, but it has %d.", expected, got)
+	}
+
+	if result.ExitCode != 1 {
+		t.Fatalf("Expected exit code to be 1, but it was %d.", result.ExitCode)
+	}
+
+	if !slices.Equal(result.LintedFiles, []string{"../testdata/if_return.go"}) {
+		t.Fatalf("Expected linted files to be [../testdata/if_return.go], but they were %v.", result.LintedFiles)
+	}
+}
+
+func TestReviveRunCanceled(t *testing.T) {
+	// ARRANGE
+	revive := getMockRevive(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// ACT
+	_, err := revive.Run(ctx, revivelib.Include("../testdata/..."))
+
+	// ASSERT
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected error to be %v, but it was %v.", context.Canceled, err)
+	}
+}
+
+func TestReviveRunOnFileSystem(t *testing.T) {
+	// ARRANGE
+	src, err := os.ReadFile("../testdata/if_return.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf, err := config.GetConfig("../defaults.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revive, err := revivelib.New(
+		conf,
+		revivelib.WithIOFS(fstest.MapFS{
+			"go.mod":              {Data: []byte("module example.com/test\n\ngo 1.22\n")},
+			"pkg/if_return.go":    {Data: src},
+			"pkg/generated.go":    {Data: []byte("// Code generated by test. DO NOT EDIT.\n\npackage pkg\n")},
+			"testdata/ignored.go": {Data: src},
+		}),
+		revivelib.WithExtraRules(revivelib.NewExtraRule(&rule.IfReturnRule{}, lint.RuleConfig{})),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// ACT
+	result, err := revive.Run(context.Background(), revivelib.Include("./..."))
+	// ASSERT
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	const expected = 5
+	if got := len(result.Failures); got != expected {
+		t.Fatalf("Expected result to have %d failures, but it has %d.", expected, got)
+	}
+
+	if !slices.Equal(result.GeneratedFiles, []string{"pkg/generated.go"}) {
+		t.Fatalf("Expected generated files to be [pkg/generated.go], but they were %v.", result.GeneratedFiles)
+	}
+}
+
+func TestReviveRunWithCache(t *testing.T) {
+	src, err := os.ReadFile("../testdata/if_return.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf, err := config.GetConfig("../defaults.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fsys := fstest.MapFS{
+		"go.mod":           {Data: []byte("module example.com/test\n\ngo 1.22\n")},
+		"pkg/if_return.go": {Data: src},
+	}
+	cache := lint.NewCache()
+	revive, err := revivelib.New(
+		conf,
+		revivelib.WithIOFS(fsys),
+		revivelib.WithExtraRules(revivelib.NewExtraRule(&rule.IfReturnRule{}, lint.RuleConfig{})),
+		revivelib.WithCache(cache),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []int{5, 5} {
+		result, err := revive.Run(context.Background(), revivelib.Include("./..."))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(result.Failures); got != want {
+			t.Fatalf("Expected result to have %d failures, but it has %d.", want, got)
+		}
+		if !slices.Equal(result.LintedFiles, []string{"pkg/if_return.go"}) {
+			t.Fatalf("Expected linted files to be [pkg/if_return.go], but they were %v.", result.LintedFiles)
+		}
+	}
+	if got := cache.Len(); got != 1 {
+		t.Fatalf("Expected 1 cached package, got %d", got)
+	}
+
+	// the overlaid file is linted again without replacing the cached package
+	overlaid, err := revive.Overlay(map[string][]byte{"pkg/if_return.go": []byte("// Package pkg is fixed.\npackage pkg\n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, step := range []struct {
+		revive *revivelib.Revive
+		want   int
+	}{{revive: overlaid, want: 0}, {revive: revive, want: 5}} {
+		result, err := step.revive.Run(context.Background(), revivelib.Include("./..."))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := len(result.Failures); got != step.want {
+			t.Fatalf("Expected result to have %d failures, but it has %d.", step.want, got)
+		}
+	}
+
+	// the changed file is only linted again once invalidated
+	fsys["pkg/if_return.go"] = &fstest.MapFile{Data: []byte("// Package pkg is fixed.\npackage pkg\n")}
+	cache.Invalidate("pkg/if_return.go")
+	result, err := revive.Run(context.Background(), revivelib.Include("./..."))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := len(result.Failures); got != 0 {
+		t.Fatalf("Expected no failure once the file is fixed, got %d", got)
+	}
+}
+
+func TestReviveRunExcludeRules(t *testing.T) {
+	conf, err := config.GetConfig("../defaults.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conf.ExcludeRules = []lint.ExcludeRule{{Rule: "if-return"}}
+	if err := conf.ExcludeRules[0].Initialize(); err != nil {
+		t.Fatal(err)
+	}
+	revive, err := revivelib.New(conf, revivelib.WithExtraRules(revivelib.NewExtraRule(&rule.IfReturnRule{}, lint.RuleConfig{})))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// every run counts its own matches
+	for range 2 {
+		result, err := revive.Run(context.Background(), revivelib.Include("../testdata/if_return.go"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if slices.ContainsFunc(result.Failures, func(failure lint.Failure) bool { return failure.RuleName == "if-return" }) {
+			t.Fatal("Expected the failures of if-return to be excluded.")
+		}
+		if got := result.ExcludeRules[0].Matches(); got != 3 {
+			t.Fatalf("Expected the entry to match 3 failures during the run, got %d", got)
+		}
+	}
+	if got := conf.ExcludeRules[0].Matches(); got != 0 {
+		t.Fatalf("Expected the entry of the configuration to match no failure, got %d", got)
+	}
+}
+
+func TestReviveRunOwners(t *testing.T) {
+	src, err := os.ReadFile("../testdata/if_return.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conf, err := config.GetConfig("../defaults.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	revive, err := revivelib.New(
+		conf,
+		revivelib.WithIOFS(fstest.MapFS{
+			"go.mod":                {Data: []byte("module example.com/test\n\ngo 1.22\n")},
+			"CODEOWNERS":            {Data: []byte("* @all\n/a/ @org/team-a # team A\nb/ @org/team-b\n")},
+			"a/if_return.go":        {Data: src},
+			"b/if_return.go":        {Data: src},
+			"c/nested/if_return.go": {Data: src},
+		}),
+		revivelib.WithExtraRules(revivelib.NewExtraRule(&rule.IfReturnRule{}, lint.RuleConfig{})),
+		revivelib.WithCodeOwners("CODEOWNERS"),
+		revivelib.WithOwners("@org/Team-A"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := revive.Run(context.Background(), revivelib.Include("./..."))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Failures) == 0 {
+		t.Fatal("Expected failures of the files of @org/team-a")
+	}
+	for _, failure := range result.Failures {
+		if failure.Filename() != "a/if_return.go" || !slices.Equal(failure.Owners, []string{"@org/team-a"}) {
+			t.Errorf("Unexpected failure of %s, owned by %v", failure.Filename(), failure.Owners)
+		}
+	}
+}
+
+func TestReviveOwnersWithoutCodeOwners(t *testing.T) {
+	conf, err := config.GetConfig("../defaults.toml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = revivelib.New(conf, revivelib.WithIOFS(fstest.MapFS{}), revivelib.WithOwners("@org/team"))
+	if err == nil || !strings.Contains(err.Error(), "CODEOWNERS") {
+		t.Fatalf("Expected an error about the missing CODEOWNERS file, got %v", err)
+	}
+}
+
+func TestReviveDiscoveredCodeOwners(t *testing.T) {
+	src, err := os.ReadFile("../testdata/if_return.go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range []struct {
+		codeOwners  string
+		formatter   string
+		wantOwners  bool
+		wantWarning bool
+	}{
+		{codeOwners: "* @all\n", formatter: "json", wantOwners: true},
+		{codeOwners: "* @all\n", formatter: "unix"},
+		{codeOwners: "!vendor/ @all\n", formatter: "json", wantWarning: true},
+		{codeOwners: "!vendor/ @all\n", formatter: "unix"},
+	} {
+		conf, err := config.GetConfig("../defaults.toml")
+		if err != nil {
+			t.Fatal(err)
+		}
+		fs := afero.NewMemMapFs()
+		for name, content := range map[string][]byte{"CODEOWNERS": []byte(tt.codeOwners), "pkg/if_return.go": src} {
+			if err := afero.WriteFile(fs, filepath.Join(wd, name), content, 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		// an invalid CODEOWNERS file of the repository does not stop revive, it is only read if the owners are written
+		var warnings strings.Builder
+		revive, err := revivelib.New(
+			conf,
+			revivelib.WithFileSystem(fs),
+			revivelib.WithExtraRules(revivelib.NewExtraRule(&rule.IfReturnRule{}, lint.RuleConfig{})),
+			revivelib.WithWarnings(&warnings),
+		)
+		if err != nil {
+			t.Fatal(err)
+		}
+		failuresChan, err := revive.Lint(revivelib.Include(filepath.Join(wd, "pkg", "if_return.go")))
+		if err != nil {
+			t.Fatal(err)
+		}
+		var out strings.Builder
+		if _, err := revive.FormatTo(&out, tt.formatter, failuresChan); err != nil {
+			t.Fatal(err)
+		}
+
+		if got := strings.Contains(out.String(), "@all"); got != tt.wantOwners {
+			t.Errorf("%s with %q: got owners %t, want %t in:\n%s", tt.formatter, tt.codeOwners, got, tt.wantOwners, out.String())
+		}
+		if got := strings.Contains(warnings.String(), "CODEOWNERS"); got != tt.wantWarning {
+			t.Errorf("%s with %q: got warnings %q", tt.formatter, tt.codeOwners, warnings.String())
+		}
+	}
+}
+
 type mockRule struct{}
 
 func (*mockRule) Name() string {
@@ -93,10 +518,12 @@ func getMockRevive(t *testing.T) *revivelib.Revive {
 
 	revive, err := revivelib.New(
 		conf,
-		true,
-		2048,
-		revivelib.NewExtraRule(&rule.IfReturnRule{}, lint.RuleConfig{}),
-		revivelib.NewExtraRule(&mockRule{}, lint.RuleConfig{}),
+		revivelib.WithSetExitStatus(true),
+		revivelib.WithMaxOpenFiles(2048),
+		revivelib.WithExtraRules(
+			revivelib.NewExtraRule(&rule.IfReturnRule{}, lint.RuleConfig{}),
+			revivelib.NewExtraRule(&mockRule{}, lint.RuleConfig{}),
+		),
 	)
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,258 @@
+package revivelib
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+const (
+	// reviveIgnoreFile is the name of the files listing the files revive ignores, with the syntax of .gitignore files.
+	reviveIgnoreFile = ".reviveignore"
+	// gitIgnoreFile is the name of the files listing the files git ignores.
+	gitIgnoreFile = ".gitignore"
+)
+
+// ignorePattern is a pattern of an ignore file.
+type ignorePattern struct {
+	rx *regexp.Regexp
+	// negate re-includes the files matching the pattern
+	negate bool
+	// dirOnly only matches directories
+	dirOnly bool
+}
+
+// ignoreMatcher tells if files are ignored by the ignore files of the directories
+// from the root of the repository of the working directory, with the semantics of .gitignore files:
+// the last matching pattern of the deepest ignore file wins, and the files of an ignored directory are ignored.
+type ignoreMatcher struct {
+	fs    afero.Fs
+	names []string
+	// root is the root of the repository, relative to the working directory
+	root string
+	// absRoot is the absolute path of the root
+	absRoot string
+	// patterns are the patterns of the ignore files, by directory relative to the root
+	patterns map[string][]ignorePattern
+	// ignoredDirs caches if the directories, relative to the root, are ignored
+	ignoredDirs map[string]bool
+	// err is the first error reading an ignore file
+	err error
+}
+
+// newIgnoreMatcher returns a matcher of the .reviveignore files, and of the .gitignore files if gitIgnore is set.
+func newIgnoreMatcher(fsys afero.Fs, gitIgnore bool) *ignoreMatcher {
+	names := []string{reviveIgnoreFile}
+	if gitIgnore {
+		names = []string{gitIgnoreFile, reviveIgnoreFile}
+	}
+
+	m := &ignoreMatcher{
+		fs:          fsys,
+		names:       names,
+		root:        ".",
+		patterns:    map[string][]ignorePattern{},
+		ignoredDirs: map[string]bool{},
+	}
+	m.absRoot, m.err = filepath.Abs(".")
+	if m.err != nil {
+		return m
+	}
+
+	// the root is the closest directory with a .git entry, the working directory if there is none
+	for dir, rel := m.absRoot, "."; ; dir, rel = filepath.Dir(dir), filepath.Join(rel, "..") {
+		if _, err := fsys.Stat(filepath.Join(rel, ".git")); err == nil {
+			m.root, m.absRoot = rel, dir
+			break
+		}
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+	return m
+}
+
+// isIgnored tells if the file or the directory is ignored.
+// Files outside of the root are not ignored.
+func (m *ignoreMatcher) isIgnored(name string, isDir bool) bool {
+	if m.err != nil {
+		return false
+	}
+
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(m.absRoot, abs)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return false
+	}
+
+	elems := strings.Split(filepath.ToSlash(rel), "/")
+	for i := range len(elems) - 1 {
+		if m.isIgnoredDir(path.Join(elems[:i+1]...)) {
+			return true
+		}
+	}
+	if isDir {
+		return m.isIgnoredDir(rel)
+	}
+	return m.matches(elems, false)
+}
+
+// isIgnoredDir tells if the directory, relative to the root, is ignored, ignoring the directories above it.
+func (m *ignoreMatcher) isIgnoredDir(dir string) bool {
+	ignored, ok := m.ignoredDirs[dir]
+	if !ok {
+		ignored = m.matches(strings.Split(dir, "/"), true)
+		m.ignoredDirs[dir] = ignored
+	}
+	return ignored
+}
+
+// matches tells if the path, given as its elements relative to the root, matches the patterns of the ignore files.
+func (m *ignoreMatcher) matches(elems []string, isDir bool) bool {
+	ignored := false
+	for i := range elems {
+		// the patterns of an ignore file are relative to its directory
+		dir, name := path.Join(elems[:i]...), path.Join(elems[i:]...)
+		for _, pattern := range m.patternsOf(dir) {
+			if pattern.dirOnly && !isDir {
+				continue
+			}
+			if pattern.rx.MatchString(name) {
+				ignored = !pattern.negate
+			}
+		}
+	}
+	return ignored
+}
+
+// patternsOf returns the patterns of the ignore files of the directory, relative to the root.
+func (m *ignoreMatcher) patternsOf(dir string) []ignorePattern {
+	if patterns, ok := m.patterns[dir]; ok {
+		return patterns
+	}
+
+	var patterns []ignorePattern
+	for _, name := range m.names {
+		filename := filepath.Join(m.root, filepath.FromSlash(dir), name)
+		content, err := afero.ReadFile(m.fs, filename)
+		if err != nil {
+			if !errors.Is(err, fs.ErrNotExist) && !errors.Is(err, fs.ErrInvalid) && m.err == nil {
+				m.err = fmt.Errorf("reading %s: %w", filename, err)
+			}
+			continue
+		}
+
+		filePatterns, err := parseIgnoreFile(content)
+		if err != nil {
+			if m.err == nil {
+				m.err = fmt.Errorf("%s: %w", filename, err)
+			}
+			continue
+		}
+		patterns = append(patterns, filePatterns...)
+	}
+
+	m.patterns[dir] = patterns
+	return patterns
+}
+
+// parseIgnoreFile parses the patterns of an ignore file.
+func parseIgnoreFile(content []byte) ([]ignorePattern, error) {
+	var patterns []ignorePattern
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for number := 1; scanner.Scan(); number++ {
+		pattern, ok, err := parseIgnorePattern(scanner.Text())
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", number, err)
+		}
+		if ok {
+			patterns = append(patterns, pattern)
+		}
+	}
+	return patterns, scanner.Err()
+}
+
+// parseIgnorePattern parses a line of an ignore file. It returns false for blank lines and comments.
+func parseIgnorePattern(line string) (ignorePattern, bool, error) {
+	line = strings.TrimSuffix(line, "\r")
+	// trailing spaces are ignored unless escaped
+	trimmed := strings.TrimRight(line, " ")
+	if strings.HasSuffix(trimmed, `\`) && len(trimmed) < len(line) {
+		trimmed += " "
+	}
+	line = trimmed
+	if line == "" || strings.HasPrefix(line, "#") {
+		return ignorePattern{}, false, nil
+	}
+
+	var pattern ignorePattern
+	if strings.HasPrefix(line, "!") {
+		pattern.negate = true
+		line = line[1:]
+	}
+	if strings.HasSuffix(line, "/") {
+		pattern.dirOnly = true
+		line = strings.TrimSuffix(line, "/")
+	}
+	// a pattern with a separator is relative to the directory of the ignore file,
+	// one without matches at any level below it
+	anchored := strings.Contains(line, "/")
+	line = strings.TrimPrefix(line, "/")
+
+	var rx strings.Builder
+	rx.WriteString("^")
+	if !anchored {
+		rx.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		atElemStart := i == 0 || line[i-1] == '/'
+		switch {
+		case atElemStart && strings.HasPrefix(line[i:], "**/"):
+			rx.WriteString("(?:.*/)?")
+			i += 2
+		case atElemStart && line[i:] == "**":
+			rx.WriteString(".*")
+			i++
+		case c == '*':
+			rx.WriteString("[^/]*")
+		case c == '?':
+			rx.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(line[i+1:], ']')
+			if end < 0 {
+				rx.WriteString(`\[`)
+				continue
+			}
+			class := line[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			rx.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(line):
+			rx.WriteString(regexp.QuoteMeta(line[i+1 : i+2]))
+			i++
+		default:
+			rx.WriteString(regexp.QuoteMeta(line[i : i+1]))
+		}
+	}
+	rx.WriteString("$")
+
+	var err error
+	if pattern.rx, err = regexp.Compile(rx.String()); err != nil {
+		return ignorePattern{}, false, fmt.Errorf("invalid pattern %q: %w", line, err)
+	}
+	return pattern, true, nil
+}
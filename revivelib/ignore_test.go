@@ -0,0 +1,134 @@
+package revivelib
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestParseIgnorePattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		matches []string
+		misses  []string
+	}{
+		{pattern: "*.gen.go", matches: []string{"a.gen.go", "x/y/a.gen.go"}, misses: []string{"a.go", "a.gen.go/x"}},
+		{pattern: "/build", matches: []string{"build"}, misses: []string{"x/build"}},
+		{pattern: "docs/*.go", matches: []string{"docs/a.go"}, misses: []string{"docs/x/a.go", "x/docs/a.go"}},
+		{pattern: "**/scratch", matches: []string{"scratch", "x/y/scratch"}, misses: []string{"myscratch"}},
+		{pattern: "third_party/**", matches: []string{"third_party/a", "third_party/x/a.go"}, misses: []string{"third_party"}},
+		{pattern: "a/**/b", matches: []string{"a/b", "a/x/y/b"}, misses: []string{"a/xb"}},
+		{pattern: "file?.go", matches: []string{"file1.go"}, misses: []string{"file10.go", "file/.go"}},
+		{pattern: "file[0-9].go", matches: []string{"file1.go"}, misses: []string{"filea.go"}},
+		{pattern: "file[!0-9].go", matches: []string{"filea.go"}, misses: []string{"file1.go"}},
+		{pattern: `\#hash`, matches: []string{"#hash"}},
+		{pattern: `\!bang`, matches: []string{"!bang"}},
+		{pattern: `space\ `, matches: []string{"space "}},
+		{pattern: "trailing   ", matches: []string{"trailing"}},
+	}
+	for _, tt := range tests {
+		pattern, ok, err := parseIgnorePattern(tt.pattern)
+		if err != nil || !ok {
+			t.Fatalf("parseIgnorePattern(%q) = %v, %v", tt.pattern, ok, err)
+		}
+		for _, name := range tt.matches {
+			if !pattern.rx.MatchString(name) {
+				t.Errorf("%q should match %q", tt.pattern, name)
+			}
+		}
+		for _, name := range tt.misses {
+			if pattern.rx.MatchString(name) {
+				t.Errorf("%q should not match %q", tt.pattern, name)
+			}
+		}
+	}
+
+	for _, line := range []string{"", "   ", "# comment"} {
+		if _, ok, err := parseIgnorePattern(line); ok || err != nil {
+			t.Errorf("parseIgnorePattern(%q) = %v, %v, want no pattern", line, ok, err)
+		}
+	}
+
+	pattern, _, _ := parseIgnorePattern("!out/")
+	if !pattern.negate || !pattern.dirOnly {
+		t.Errorf("got %+v, want a negated pattern of directories", pattern)
+	}
+}
+
+func TestIgnoreMatcher(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for name, content := range map[string]string{
+		".reviveignore":         "*.gen.go\nscratch/\n!keep.gen.go\n",
+		".gitignore":            "/out\n",
+		"pkg/.reviveignore":     "local.go\n!scratch/\n",
+		"pkg/scratch/a.go":      "package scratch\n",
+		"pkg/sub/.reviveignore": "!*.gen.go\n",
+		"pkg/sub/b.gen.go":      "package sub\n",
+		"pkg/local.go":          "package pkg\n",
+		"pkg/a.gen.go":          "package pkg\n",
+		"pkg/keep.gen.go":       "package pkg\n",
+		"scratch/c.go":          "package scratch\n",
+		"out/o.go":              "package out\n",
+	} {
+		if err := afero.WriteFile(fs, filepath.FromSlash(name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		gitIgnore bool
+		ignored   bool
+	}{
+		{name: "pkg/local.go", ignored: true},
+		{name: "local.go"},
+		{name: "pkg/a.gen.go", ignored: true},
+		{name: "pkg/keep.gen.go"},
+		{name: "pkg/sub/b.gen.go"},
+		{name: "scratch/c.go", ignored: true},
+		{name: "pkg/scratch/a.go"},
+		{name: "out/o.go"},
+		{name: "out/o.go", gitIgnore: true, ignored: true},
+		{name: "./pkg/a.gen.go", ignored: true},
+	}
+	for _, tt := range tests {
+		m := newIgnoreMatcher(fs, tt.gitIgnore)
+		if got := m.isIgnored(filepath.FromSlash(tt.name), false); got != tt.ignored {
+			t.Errorf("isIgnored(%q) with .gitignore %v = %v, want %v", tt.name, tt.gitIgnore, got, tt.ignored)
+		}
+		if m.err != nil {
+			t.Errorf("unexpected error %v", m.err)
+		}
+	}
+}
+
+func TestPackageResolverIgnoreFiles(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for name, content := range map[string]string{
+		"go.mod":           "module example.com/test\n",
+		".reviveignore":    "/third_party/\nmock_*.go\n",
+		"pkg/a.go":         "package pkg\n",
+		"pkg/mock_a.go":    "package pkg\n",
+		"third_party/b.go": "package thirdparty\n",
+	} {
+		if err := afero.WriteFile(fs, filepath.FromSlash(name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	resolver := newPackageResolver(fs)
+	resolver.ignore = newIgnoreMatcher(fs, false)
+	got, err := resolver.resolvePackages([]string{"./...", "third_party"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []lint.PackageFiles{{Files: []string{"pkg/a.go"}}}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("got %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,144 @@
+package revivelib
+
+import (
+	"io"
+	"io/fs"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// Option configures a [Revive] instance.
+type Option func(*options)
+
+type options struct {
+	setExitStatus bool
+	maxOpenFiles  int
+	extraRules    []ExtraRule
+	fs            afero.Fs
+	buildTags     []string
+	goos          string
+	goarch        string
+	onFile        func(lint.FileReport)
+	sortOrder     SortOrder
+	codeOwners    string
+	owners        []string
+	gitIgnore     bool
+	cache         *lint.Cache
+	warnings      io.Writer
+}
+
+// WithSetExitStatus makes revive exit with status 1 if any issues are found,
+// overwriting errorCode and warningCode of the configuration.
+func WithSetExitStatus(setExitStatus bool) Option {
+	return func(o *options) {
+		o.setExitStatus = setExitStatus
+	}
+}
+
+// WithMaxOpenFiles limits the number of files opened at the same time.
+// Zero or a negative value means no limit.
+func WithMaxOpenFiles(maxOpenFiles int) Option {
+	return func(o *options) {
+		o.maxOpenFiles = maxOpenFiles
+	}
+}
+
+// WithExtraRules adds rules to the ones provided by revive.
+func WithExtraRules(extraRules ...ExtraRule) Option {
+	return func(o *options) {
+		o.extraRules = append(o.extraRules, extraRules...)
+	}
+}
+
+// WithFileSystem makes revive discover packages and read files from the given
+// file system instead of the one of the operating system.
+func WithFileSystem(fs afero.Fs) Option {
+	return func(o *options) {
+		o.fs = fs
+	}
+}
+
+// WithIOFS makes revive discover packages and read files from the given [fs.FS].
+// Patterns and file names must then be valid [fs.FS] paths.
+func WithIOFS(fsys fs.FS) Option {
+	return WithFileSystem(afero.FromIOFS{FS: fsys})
+}
+
+// WithBuildTags sets the build tags considered satisfied when evaluating
+// the build constraints of the files, like the -tags flag of the go command.
+func WithBuildTags(tags ...string) Option {
+	return func(o *options) {
+		o.buildTags = append(o.buildTags, tags...)
+	}
+}
+
+// WithPlatform sets the operating system and the architecture for which the build
+// constraints of the files are evaluated. Empty values stand for the ones of the host,
+// or the ones set in the GOOS and GOARCH environment variables.
+func WithPlatform(goos, goarch string) Option {
+	return func(o *options) {
+		o.goos = goos
+		o.goarch = goarch
+	}
+}
+
+// WithFileReports calls fn for every file handled by a linting run,
+// for example to gather statistics. fn can be called concurrently.
+func WithFileReports(fn func(lint.FileReport)) Option {
+	return func(o *options) {
+		o.onFile = fn
+	}
+}
+
+// WithSortOrder sets the order in which the failures are formatted, and returned by [Revive.Run].
+// The failures are sorted by position by default; [SortNone] lets the formatters stream them as they are found.
+func WithSortOrder(order SortOrder) Option {
+	return func(o *options) {
+		o.sortOrder = order
+	}
+}
+
+// WithCodeOwners sets the path of the CODEOWNERS file giving the owners of the failures,
+// overriding the one of the configuration. By default, the CODEOWNERS file of the repository
+// of the working directory is used, if any, once a formatter writing the owners formats the failures
+// or once [WithOwners] filters them.
+func WithCodeOwners(path string) Option {
+	return func(o *options) {
+		o.codeOwners = path
+	}
+}
+
+// WithOwners only reports the failures of the files owned by one of the given owners, like @org/team,
+// according to the CODEOWNERS file.
+func WithOwners(owners ...string) Option {
+	return func(o *options) {
+		o.owners = append(o.owners, owners...)
+	}
+}
+
+// WithGitIgnore makes revive skip the files ignored by the .gitignore files of the repository,
+// in addition to the ones ignored by its .reviveignore files.
+func WithGitIgnore(gitIgnore bool) Option {
+	return func(o *options) {
+		o.gitIgnore = gitIgnore
+	}
+}
+
+// WithCache makes the runs keep the packages they lint in the cache, for the next runs to only lint
+// the packages whose files changed, the ones invalidated with [lint.Cache.Invalidate].
+// The cache must only be shared by instances with the same configuration.
+func WithCache(cache *lint.Cache) Option {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+// WithWarnings writes to w the warnings about the problems that do not stop linting,
+// like a CODEOWNERS file of the repository that cannot be parsed. They are discarded by default.
+func WithWarnings(w io.Writer) Option {
+	return func(o *options) {
+		o.warnings = w
+	}
+}
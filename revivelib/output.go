@@ -0,0 +1,11 @@
+package revivelib
+
+import "io"
+
+// Output is a destination of the failures of a run.
+type Output struct {
+	// Formatter is the name of the formatter, the default one if empty.
+	Formatter string
+	// Writer is where the formatted failures are written.
+	Writer io.Writer
+}
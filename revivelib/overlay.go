@@ -0,0 +1,49 @@
+package revivelib
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/afero"
+)
+
+// Overlay returns a copy of revive reading the files of the overlays, by path, from their content instead of its file system,
+// like the unsaved buffers of an editor. The paths are absolute or relative to the working directory.
+//
+// The copy shares the configuration, the rules and the cache of revive: with [WithCache], the packages of the overlaid
+// files are linted without the cache, and the other packages are shared with revive. Overlaying other files than
+// Go files, like go.mod files, makes the copy lint all the packages without the cache.
+func (r *Revive) Overlay(overlays map[string][]byte) (*Revive, error) {
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	overlaid := *r
+	layer := afero.NewMemMapFs()
+	for name, content := range overlays {
+		abs, err := filepath.Abs(name)
+		if err != nil {
+			return nil, err
+		}
+		// the files are named like their patterns, absolute or relative
+		paths := []string{abs}
+		if rel, err := filepath.Rel(wd, abs); err == nil {
+			paths = append(paths, rel)
+		}
+		for _, path := range paths {
+			if err := afero.WriteFile(layer, path, content, 0o644); err != nil {
+				return nil, err
+			}
+		}
+
+		overlaid.uncached = append(overlaid.uncached, abs)
+		if !strings.HasSuffix(abs, ".go") {
+			overlaid.cache = nil
+		}
+	}
+	overlaid.fs = afero.NewCopyOnWriteFs(afero.NewReadOnlyFs(r.fs), layer)
+
+	return &overlaid, nil
+}
@@ -0,0 +1,140 @@
+package revivelib
+
+import (
+	"fmt"
+	"iter"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// codeOwnersLocations are the paths of the CODEOWNERS file in a repository, in the order they are looked for.
+var codeOwnersLocations = []string{
+	filepath.Join(".github", "CODEOWNERS"),
+	"CODEOWNERS",
+	filepath.Join("docs", "CODEOWNERS"),
+	filepath.Join(".gitlab", "CODEOWNERS"),
+}
+
+// FindCodeOwners returns the path of the CODEOWNERS file of the repository of the working directory in fs,
+// looking for it in the working directory and its parents up to the root of the repository.
+// It returns an empty path if there is none.
+func FindCodeOwners(fs afero.Fs) string {
+	dir, err := filepath.Abs(".")
+	if err != nil {
+		return ""
+	}
+
+	for {
+		for _, location := range codeOwnersLocations {
+			path := filepath.Join(dir, location)
+			if info, err := fs.Stat(path); err == nil && !info.IsDir() {
+				return path
+			}
+		}
+		if _, err := fs.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// loadCodeOwners reads the CODEOWNERS file at path.
+func loadCodeOwners(fs afero.Fs, path string) (*lint.CodeOwners, error) {
+	content, err := afero.ReadFile(fs, path)
+	if err != nil {
+		return nil, err
+	}
+
+	// the patterns are relative to the root of the repository
+	root := filepath.Dir(path)
+	if slices.Contains([]string{".github", ".gitlab", "docs"}, filepath.Base(root)) {
+		root = filepath.Dir(root)
+	}
+
+	codeOwners, err := lint.ParseCodeOwners(root, content)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return codeOwners, nil
+}
+
+// discoveredCodeOwners is the CODEOWNERS file of the repository of the working directory,
+// only looked for once a formatter writes the owners of the failures.
+type discoveredCodeOwners struct {
+	once       sync.Once
+	codeOwners *lint.CodeOwners
+}
+
+// codeOwnersFor returns the CODEOWNERS file giving the owners of the failures, nil if there is none.
+// Without a CODEOWNERS file set, the one of the repository is looked for if the owners are written;
+// as it was not asked for, a file that cannot be read or parsed is reported as a warning.
+func (r *Revive) codeOwnersFor(writesOwners bool) *lint.CodeOwners {
+	if r.codeOwners != nil || !writesOwners || r.discovered == nil {
+		return r.codeOwners
+	}
+
+	r.discovered.once.Do(func() {
+		path := FindCodeOwners(r.fs)
+		if path == "" {
+			return
+		}
+		codeOwners, err := loadCodeOwners(r.fs, path)
+		if err != nil {
+			r.logger.Warn("Ignoring the CODEOWNERS file", "error", err)
+			fmt.Fprintf(r.warnings, "warning: ignoring the CODEOWNERS file of the repository: %v\n", err)
+			return
+		}
+		r.discovered.codeOwners = codeOwners
+	})
+	return r.discovered.codeOwners
+}
+
+// ownedBy tells if revive reports the files with the given owners.
+func (r *Revive) ownedBy(owners []string) bool {
+	return len(r.owners) == 0 || slices.ContainsFunc(r.owners, func(owner string) bool {
+		return lint.HasOwner(owners, owner)
+	})
+}
+
+// ownedFailures sets the owners of the failures from the CODEOWNERS file, keeping only the failures
+// of the owners set with [WithOwners]. The internal failures are always kept.
+func (r *Revive) ownedFailures(codeOwners *lint.CodeOwners, failures iter.Seq[lint.Failure]) iter.Seq[lint.Failure] {
+	if codeOwners == nil {
+		return failures
+	}
+
+	return func(yield func(lint.Failure) bool) {
+		for failure := range failures {
+			if !failure.IsInternal() {
+				failure.Owners = codeOwners.Owners(failure.Filename())
+				if !r.ownedBy(failure.Owners) {
+					continue
+				}
+			}
+			if !yield(failure) {
+				return
+			}
+		}
+	}
+}
+
+// ownedFiles returns the files of the owners set with [WithOwners].
+func (r *Revive) ownedFiles(files []lint.FileReport) []lint.FileReport {
+	if len(r.owners) == 0 {
+		return files
+	}
+
+	return slices.DeleteFunc(files, func(file lint.FileReport) bool {
+		return !r.ownedBy(r.codeOwners.Owners(file.Name))
+	})
+}
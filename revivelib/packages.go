@@ -0,0 +1,324 @@
+package revivelib
+
+import (
+	"errors"
+	"fmt"
+	"go/build"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// packageResolver finds the Go files of the packages matching a set of patterns.
+//
+// A pattern can be a file, a directory, a directory followed by "/..." to include
+// all its subdirectories, or an import path.
+type packageResolver struct {
+	fs  afero.Fs
+	ctx build.Context
+	// ignore skips the files ignored by the ignore files, if set
+	ignore *ignoreMatcher
+}
+
+func newPackageResolver(fs afero.Fs) *packageResolver {
+	ctx := build.Default
+	if _, isOsFs := fs.(*afero.OsFs); !isOsFs {
+		ctx.IsDir = func(path string) bool {
+			isDir, err := afero.IsDir(fs, path)
+			return err == nil && isDir
+		}
+		ctx.ReadDir = func(dir string) ([]os.FileInfo, error) {
+			return afero.ReadDir(fs, dir)
+		}
+		ctx.OpenFile = func(path string) (io.ReadCloser, error) {
+			return fs.Open(path)
+		}
+	}
+
+	return &packageResolver{
+		fs:  fs,
+		ctx: ctx,
+	}
+}
+
+// setPlatform sets the operating system and the architecture the build constraints are evaluated for.
+// Empty values keep the ones of the host, or the ones of the GOOS and GOARCH environment variables.
+func (pr *packageResolver) setPlatform(goos, goarch string) {
+	isCrossPlatform := (goos != "" && goos != pr.ctx.GOOS) || (goarch != "" && goarch != pr.ctx.GOARCH)
+	if goos != "" {
+		pr.ctx.GOOS = goos
+	}
+	if goarch != "" {
+		pr.ctx.GOARCH = goarch
+	}
+	if isCrossPlatform && os.Getenv("CGO_ENABLED") != "1" {
+		// like the go command, disable cgo when building for another platform
+		pr.ctx.CgoEnabled = false
+	}
+}
+
+// resolvePackages returns the files of the packages matching the include patterns,
+// grouped by package, without the files matching the exclude patterns.
+//
+// The test files of a package are grouped apart: in-package test files in a package
+// type-checked along with the non-test files, and external test files in another package.
+func (pr *packageResolver) resolvePackages(includePatterns, excludePatterns []string) ([]lint.PackageFiles, error) {
+	excluded := map[string]bool{}
+	for _, pattern := range excludePatterns {
+		packages, err := pr.resolvePattern(pattern)
+		if err != nil {
+			// the pattern does not match anything, thus there is nothing to exclude
+			continue
+		}
+		for _, pkg := range packages {
+			for _, file := range pkg.Files {
+				excluded[file] = true
+			}
+		}
+	}
+
+	isExcluded := func(file string) bool {
+		base := filepath.Base(file)
+		if excluded[base] || excluded[file] {
+			return true
+		}
+		if base != "." && base != ".." && strings.ContainsAny(base[0:1], "_.") {
+			return true
+		}
+		return pr.ignore != nil && pr.ignore.isIgnored(file, false)
+	}
+
+	seen := map[string]bool{}
+	var result []lint.PackageFiles
+	for _, pattern := range includePatterns {
+		packages, err := pr.resolvePattern(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, pkg := range packages {
+			var files []string
+			for _, file := range pkg.Files {
+				if seen[file] || isExcluded(file) {
+					continue
+				}
+				seen[file] = true
+				files = append(files, file)
+			}
+			if len(files) == 0 {
+				continue
+			}
+			result = append(result, lint.PackageFiles{Files: files, TypeCheckFiles: pkg.TypeCheckFiles})
+		}
+	}
+
+	if pr.ignore != nil && pr.ignore.err != nil {
+		return nil, fmt.Errorf("reading the ignore files: %w", pr.ignore.err)
+	}
+
+	return result, nil
+}
+
+func (pr *packageResolver) resolvePattern(pattern string) ([]lint.PackageFiles, error) {
+	var dirs []string
+	switch {
+	case strings.HasSuffix(pattern, "/...") && pr.isDir(strings.TrimSuffix(pattern, "/...")):
+		dirs = pr.matchDirs(pattern)
+	case pr.isDir(pattern):
+		dirs = []string{pattern}
+	case pr.exists(pattern):
+		return []lint.PackageFiles{{Files: []string{pattern}}}, nil
+	default:
+		return pr.resolveImportPaths(pattern)
+	}
+
+	result := []lint.PackageFiles{}
+	for _, dir := range dirs {
+		packages, err := pr.resolveDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, packages...)
+	}
+
+	return result, nil
+}
+
+func (pr *packageResolver) resolveImportPaths(pattern string) ([]lint.PackageFiles, error) {
+	importPath := filepath.ToSlash(pattern)
+	if strings.HasPrefix(importPath, "./") {
+		importPath = "./" + path.Clean(importPath)
+	} else {
+		importPath = path.Clean(importPath)
+	}
+
+	importPaths := []string{importPath}
+	if strings.Contains(importPath, "...") {
+		importPaths = pr.matchImportPaths(importPath)
+	}
+
+	result := []lint.PackageFiles{}
+	for _, importPath := range importPaths {
+		pkg, err := pr.ctx.Import(importPath, ".", 0)
+		packages, err := packageFiles(pkg, err)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, packages...)
+	}
+
+	return result, nil
+}
+
+func (pr *packageResolver) resolveDir(dir string) ([]lint.PackageFiles, error) {
+	pkg, err := pr.ctx.ImportDir(dir, 0)
+	return packageFiles(pkg, err)
+}
+
+// packageFiles splits the files of the package, satisfying the build constraints,
+// into the package itself, its in-package tests and its external tests.
+func packageFiles(pkg *build.Package, err error) ([]lint.PackageFiles, error) {
+	if err != nil {
+		var noGoErr *build.NoGoError
+		if errors.As(err, &noGoErr) {
+			// Don't complain if the failure is due to no Go source files.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("resolving package files: %w", err)
+	}
+
+	paths := func(names ...[]string) []string {
+		var files []string
+		for _, name := range slices.Concat(names...) {
+			if pkg.Dir != "." {
+				name = filepath.Join(pkg.Dir, name)
+			}
+			files = append(files, name)
+		}
+		return files
+	}
+
+	files := paths(pkg.GoFiles, pkg.CgoFiles)
+	result := []lint.PackageFiles{}
+	for _, p := range []lint.PackageFiles{
+		{Files: files},
+		{Files: paths(pkg.TestGoFiles), TypeCheckFiles: files},
+		{Files: paths(pkg.XTestGoFiles)},
+	} {
+		if len(p.Files) > 0 {
+			result = append(result, p)
+		}
+	}
+
+	return result, nil
+}
+
+// matchDirs returns the directories containing Go packages that match the given
+// pattern of the form dir/... where dir is a directory of the file system.
+func (pr *packageResolver) matchDirs(pattern string) []string {
+	pattern = filepath.ToSlash(pattern)
+	root, _ := path.Split(pattern[:strings.Index(pattern, "...")])
+	// path.Clean discards the leading ./ but it is needed to match the pattern
+	prefix := ""
+	if strings.HasPrefix(pattern, "./") {
+		prefix = "./"
+	}
+	match := matchPattern(pattern)
+
+	var dirs []string
+	afero.Walk(pr.fs, filepath.Clean(filepath.FromSlash(root)), func(dir string, fi os.FileInfo, err error) error {
+		if err != nil || !fi.IsDir() {
+			return nil
+		}
+
+		if isSkippedDir(dir) || (pr.ignore != nil && pr.ignore.isIgnored(dir, true)) {
+			return filepath.SkipDir
+		}
+
+		if !match(prefix + filepath.ToSlash(filepath.Clean(dir))) {
+			return nil
+		}
+
+		if _, err := pr.ctx.ImportDir(dir, 0); err != nil {
+			return nil
+		}
+		dirs = append(dirs, prefix+filepath.ToSlash(filepath.Clean(dir)))
+		return nil
+	})
+
+	return dirs
+}
+
+// matchImportPaths returns the import paths of the packages, found in the Go source
+// directories, that match the given pattern.
+func (pr *packageResolver) matchImportPaths(pattern string) []string {
+	match := matchPattern(pattern)
+
+	var importPaths []string
+	for _, srcDir := range pr.ctx.SrcDirs() {
+		srcDir = filepath.Clean(srcDir) + string(filepath.Separator)
+		afero.Walk(pr.fs, srcDir, func(dir string, fi os.FileInfo, err error) error {
+			if err != nil || !fi.IsDir() || dir == srcDir {
+				return nil
+			}
+
+			if isSkippedDir(dir) {
+				return filepath.SkipDir
+			}
+
+			importPath := filepath.ToSlash(dir[len(srcDir):])
+			if !match(importPath) {
+				return nil
+			}
+
+			if _, err := pr.ctx.ImportDir(dir, 0); err != nil {
+				var noGoErr *build.NoGoError
+				if errors.As(err, &noGoErr) {
+					return nil
+				}
+			}
+			importPaths = append(importPaths, importPath)
+			return nil
+		})
+	}
+
+	return importPaths
+}
+
+func (pr *packageResolver) isDir(name string) bool {
+	isDir, err := afero.IsDir(pr.fs, name)
+	return err == nil && isDir
+}
+
+func (pr *packageResolver) exists(name string) bool {
+	exists, err := afero.Exists(pr.fs, name)
+	return err == nil && exists
+}
+
+// isSkippedDir returns true for .foo, _foo, and testdata directories, but not for "." or "..".
+func isSkippedDir(dir string) bool {
+	elem := filepath.Base(dir)
+	isDot := strings.HasPrefix(elem, ".") && elem != "." && elem != ".."
+	return isDot || strings.HasPrefix(elem, "_") || elem == "testdata"
+}
+
+// matchPattern returns a function reporting whether a name matches the pattern.
+// The pattern is a limited glob pattern in which "..." means "any string".
+func matchPattern(pattern string) func(name string) bool {
+	re := regexp.QuoteMeta(pattern)
+	re = strings.ReplaceAll(re, `\.\.\.`, `.*`)
+	// Special case: foo/... matches foo too.
+	if strings.HasSuffix(re, `/.*`) {
+		re = re[:len(re)-len(`/.*`)] + `(/.*)?`
+	}
+	rx := regexp.MustCompile(`^` + re + `$`)
+	return rx.MatchString
+}
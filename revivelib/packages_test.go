@@ -0,0 +1,121 @@
+package revivelib
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/spf13/afero"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestPackageResolver(t *testing.T) {
+	fs := afero.NewMemMapFs()
+	for name, content := range map[string]string{
+		"go.mod":              "module example.com/test\n",
+		"main.go":             "package main\n",
+		"pkg/a.go":            "package pkg\n",
+		"pkg/a_test.go":       "package pkg\n",
+		"pkg/b.go":            "package pkg\n",
+		"pkg/x_test.go":       "package pkg_test\n",
+		"pkg/tagged.go":       "//go:build integration\n\npackage pkg\n",
+		"pkg/z_windows.go":    "package pkg\n",
+		"pkg/z_linux.go":      "package pkg\n",
+		"pkg/sub/c.go":        "package sub\n",
+		"pkg/testdata/d.go":   "package testdata\n",
+		"pkg/_skipped/e.go":   "package skipped\n",
+		"pkg/.hidden/f.go":    "package hidden\n",
+		"empty/README.md":     "# empty\n",
+		"ignored/ignored.go":  "//go:build ignore\n\npackage main\n",
+		"platform/windows.go": "//go:build windows\n\npackage platform\n",
+	} {
+		if err := afero.WriteFile(fs, filepath.FromSlash(name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		include  []string
+		exclude  []string
+		tags     []string
+		goos     string
+		expected []lint.PackageFiles
+	}{
+		{
+			name:    "directory",
+			include: []string{"pkg"},
+			goos:    "linux",
+			expected: []lint.PackageFiles{
+				{Files: []string{"pkg/a.go", "pkg/b.go", "pkg/z_linux.go"}},
+				{Files: []string{"pkg/a_test.go"}, TypeCheckFiles: []string{"pkg/a.go", "pkg/b.go", "pkg/z_linux.go"}},
+				{Files: []string{"pkg/x_test.go"}},
+			},
+		},
+		{
+			name:    "directory with build tags for another platform",
+			include: []string{"pkg"},
+			tags:    []string{"integration"},
+			goos:    "windows",
+			expected: []lint.PackageFiles{
+				{Files: []string{"pkg/a.go", "pkg/b.go", "pkg/tagged.go", "pkg/z_windows.go"}},
+				{Files: []string{"pkg/a_test.go"}, TypeCheckFiles: []string{"pkg/a.go", "pkg/b.go", "pkg/tagged.go", "pkg/z_windows.go"}},
+				{Files: []string{"pkg/x_test.go"}},
+			},
+		},
+		{
+			name:    "recursive",
+			include: []string{"./..."},
+			goos:    "linux",
+			expected: []lint.PackageFiles{
+				{Files: []string{"main.go"}},
+				{Files: []string{"pkg/a.go", "pkg/b.go", "pkg/z_linux.go"}},
+				{Files: []string{"pkg/a_test.go"}, TypeCheckFiles: []string{"pkg/a.go", "pkg/b.go", "pkg/z_linux.go"}},
+				{Files: []string{"pkg/x_test.go"}},
+				{Files: []string{"pkg/sub/c.go"}},
+			},
+		},
+		{
+			name:    "recursive with excludes",
+			include: []string{"./pkg/..."},
+			exclude: []string{"pkg/sub/...", "pkg/b.go", "pkg/x_test.go"},
+			goos:    "linux",
+			expected: []lint.PackageFiles{
+				{Files: []string{"pkg/a.go", "pkg/z_linux.go"}},
+				{Files: []string{"pkg/a_test.go"}, TypeCheckFiles: []string{"pkg/a.go", "pkg/b.go", "pkg/z_linux.go"}},
+			},
+		},
+		{
+			name:    "file",
+			include: []string{"pkg/b.go"},
+			expected: []lint.PackageFiles{
+				{Files: []string{"pkg/b.go"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver := newPackageResolver(fs)
+			resolver.setPlatform(tt.goos, "")
+			resolver.ctx.BuildTags = tt.tags
+
+			got, err := resolver.resolvePackages(tt.include, tt.exclude)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, pkg := range tt.expected {
+				for _, files := range [][]string{pkg.Files, pkg.TypeCheckFiles} {
+					for i, f := range files {
+						files[i] = filepath.FromSlash(f)
+					}
+				}
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("got %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
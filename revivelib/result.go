@@ -0,0 +1,31 @@
+package revivelib
+
+import (
+	"time"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// Result is the outcome of a synchronous linting run.
+type Result struct {
+	// Failures holds the failures with a confidence at least equal to the configured one, in the sort order of revive.
+	// They have the owners of the CODEOWNERS file set with [WithCodeOwners] or in the configuration,
+	// or of the one of the repository with [WithOwners], which then only keeps the failures of the files of these owners.
+	Failures []lint.Failure
+	// Counts holds the number of failures by severity.
+	Counts map[lint.Severity]int
+	// ExitCode is the exit code revive's CLI would return for this run.
+	ExitCode int
+	// StartTime is the moment the run started.
+	StartTime time.Time
+	// Duration is the time spent linting.
+	Duration time.Duration
+	// LintedFiles holds the names of the linted files.
+	LintedFiles []string
+	// SkippedFiles holds the names of the files that were not linted because they cannot be parsed.
+	SkippedFiles []string
+	// GeneratedFiles holds the names of the files that were not linted because they contain generated code.
+	GeneratedFiles []string
+	// ExcludeRules holds the [[exclude-rule]] entries of the configuration, counting the failures they excluded during the run.
+	ExcludeRules []lint.ExcludeRule
+}
@@ -0,0 +1,91 @@
+package revivelib
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+	"strings"
+
+	"github.com/mgechev/revive/lint"
+)
+
+// SortOrder is the order in which the failures are formatted.
+type SortOrder string
+
+const (
+	// SortByPosition sorts the failures by file, line, column, then rule. It is the default order.
+	SortByPosition SortOrder = "position"
+	// SortByRule groups the failures by rule, each rule sorted by position.
+	SortByRule SortOrder = "rule"
+	// SortBySeverity puts the errors before the warnings, each severity sorted by position.
+	SortBySeverity SortOrder = "severity"
+	// SortNone keeps the order in which the failures are found,
+	// which lets the formatters stream the failures as they are found.
+	SortNone SortOrder = "none"
+)
+
+// SortOrders are the available orders of the failures.
+var SortOrders = []SortOrder{SortByPosition, SortByRule, SortBySeverity, SortNone}
+
+// compareFailurePositions compares the failures by file, line, column, and rule.
+// The other fields break the ties, for the order to be deterministic.
+func compareFailurePositions(a, b lint.Failure) int {
+	return cmp.Or(
+		strings.Compare(a.Filename(), b.Filename()),
+		cmp.Compare(a.Position.Start.Line, b.Position.Start.Line),
+		cmp.Compare(a.Position.Start.Column, b.Position.Start.Column),
+		strings.Compare(a.RuleName, b.RuleName),
+		cmp.Compare(a.Position.End.Line, b.Position.End.Line),
+		cmp.Compare(a.Position.End.Column, b.Position.End.Column),
+		strings.Compare(a.Failure, b.Failure),
+	)
+}
+
+// sortFailures sorts the failures in the sort order of revive.
+func (r *Revive) sortFailures(failures []lint.Failure) {
+	switch r.sortOrder {
+	case SortNone:
+	case SortByRule:
+		slices.SortStableFunc(failures, func(a, b lint.Failure) int {
+			return cmp.Or(strings.Compare(a.RuleName, b.RuleName), compareFailurePositions(a, b))
+		})
+	case SortBySeverity:
+		rank := func(failure lint.Failure) int {
+			if r.severity(failure) == lint.SeverityError {
+				return 0
+			}
+			return 1
+		}
+		slices.SortStableFunc(failures, func(a, b lint.Failure) int {
+			return cmp.Or(cmp.Compare(rank(a), rank(b)), compareFailurePositions(a, b))
+		})
+	default:
+		slices.SortStableFunc(failures, compareFailurePositions)
+	}
+}
+
+// orderedFailures returns the failures of the channel in the sort order of revive.
+// Unless the failures are not sorted, the channel is read until it is closed before the first failure is returned.
+func (r *Revive) orderedFailures(failures <-chan lint.Failure) iter.Seq[lint.Failure] {
+	return func(yield func(lint.Failure) bool) {
+		if r.sortOrder == SortNone {
+			for failure := range failures {
+				if !yield(failure) {
+					return
+				}
+			}
+			return
+		}
+
+		var all []lint.Failure
+		for failure := range failures {
+			all = append(all, failure)
+		}
+		r.sortFailures(all)
+		for _, failure := range all {
+			if !yield(failure) {
+				return
+			}
+		}
+	}
+}
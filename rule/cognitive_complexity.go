@@ -1,9 +1,11 @@
 package rule
 
 import (
+	"cmp"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"slices"
 
 	"golang.org/x/tools/go/ast/astutil"
 
@@ -68,6 +70,7 @@ func (w cognitiveComplexityLinter) lintCognitiveComplexity() {
 	for _, decl := range f.AST.Decls {
 		if fn, ok := decl.(*ast.FuncDecl); ok && fn.Body != nil {
 			v := cognitiveComplexityVisitor{
+				file: f,
 				name: fn.Name,
 			}
 			c := v.subTreeComplexity(fn.Body)
@@ -77,6 +80,7 @@ func (w cognitiveComplexityLinter) lintCognitiveComplexity() {
 					Category:   lint.FailureCategoryMaintenance,
 					Failure:    fmt.Sprintf("function %s has cognitive complexity %d (> max enabled %d)", funcName(fn), c, w.maxComplexity),
 					Node:       fn,
+					Related:    v.increments,
 				})
 			}
 		}
@@ -84,9 +88,12 @@ func (w cognitiveComplexityLinter) lintCognitiveComplexity() {
 }
 
 type cognitiveComplexityVisitor struct {
+	file         *lint.File
 	name         *ast.Ident
 	complexity   int
 	nestingLevel int
+	// increments are the locations increasing the complexity, with their weight
+	increments []lint.RelatedLocation
 }
 
 // subTreeComplexity calculates the cognitive complexity of an AST-subtree.
@@ -102,36 +109,42 @@ func (v *cognitiveComplexityVisitor) Visit(n ast.Node) ast.Visitor {
 		v.walkIfElse(n)
 		return nil
 	case *ast.ForStmt:
-		targets := []ast.Node{n.Cond, n.Body}
-		v.walk(1, targets...)
+		v.increment(n.For, keywordEnd(n.For, token.FOR), "for", v.nestingLevel)
+		v.walk(n.Cond, n.Body)
 		return nil
 	case *ast.RangeStmt:
-		v.walk(1, n.Body)
+		v.increment(n.For, keywordEnd(n.For, token.FOR), "for", v.nestingLevel)
+		v.walk(n.Body)
 		return nil
 	case *ast.SelectStmt:
-		v.walk(1, n.Body)
+		v.increment(n.Select, keywordEnd(n.Select, token.SELECT), "select", v.nestingLevel)
+		v.walk(n.Body)
 		return nil
 	case *ast.SwitchStmt:
-		v.walk(1, n.Body)
+		v.increment(n.Switch, keywordEnd(n.Switch, token.SWITCH), "switch", v.nestingLevel)
+		v.walk(n.Body)
 		return nil
 	case *ast.TypeSwitchStmt:
-		v.walk(1, n.Body)
+		v.increment(n.Switch, keywordEnd(n.Switch, token.SWITCH), "switch", v.nestingLevel)
+		v.walk(n.Body)
 		return nil
 	case *ast.FuncLit:
-		v.walk(0, n.Body) // do not increment the complexity, just do the nesting
+		v.walk(n.Body) // do not increment the complexity, just do the nesting
 		return nil
 	case *ast.BinaryExpr:
-		v.complexity += v.binExpComplexity(n)
+		for _, op := range v.binExpComplexity(n) {
+			v.increment(op.OpPos, keywordEnd(op.OpPos, op.Op), op.Op.String(), 0)
+		}
 		return nil // skip visiting binexp subtree (already visited by binExpComplexity)
 	case *ast.BranchStmt:
 		if n.Label != nil {
-			v.complexity++
+			v.increment(n.Pos(), n.End(), n.Tok.String()+" with label", 0)
 		}
 	case *ast.CallExpr:
 		if ident, ok := n.Fun.(*ast.Ident); ok {
 			if ident.Obj == v.name.Obj && ident.Name == v.name.Name {
 				// called by same function directly (direct recursion)
-				v.complexity++
+				v.increment(ident.Pos(), ident.End(), "recursion", 0)
 				return nil
 			}
 		}
@@ -140,8 +153,28 @@ func (v *cognitiveComplexityVisitor) Visit(n ast.Node) ast.Visitor {
 	return v
 }
 
-func (v *cognitiveComplexityVisitor) walk(complexityIncrement int, targets ...ast.Node) {
-	v.complexity += complexityIncrement + v.nestingLevel
+// increment increases the complexity by one plus the nesting, for the code from start to end.
+func (v *cognitiveComplexityVisitor) increment(start, end token.Pos, reason string, nesting int) {
+	weight := 1 + nesting
+	v.complexity += weight
+
+	message := fmt.Sprintf("+%d (%s)", weight, reason)
+	if nesting > 0 {
+		message = fmt.Sprintf("+%d (%s, nesting %d)", weight, reason, nesting)
+	}
+	v.increments = append(v.increments, lint.RelatedLocation{
+		Message:  message,
+		Position: lint.ToFailurePosition(start, end, v.file),
+	})
+}
+
+// keywordEnd returns the end of the keyword or operator at pos.
+func keywordEnd(pos token.Pos, tok token.Token) token.Pos {
+	return pos + token.Pos(len(tok.String()))
+}
+
+// walk walks the targets one nesting level deeper.
+func (v *cognitiveComplexityVisitor) walk(targets ...ast.Node) {
 	nesting := v.nestingLevel
 	v.nestingLevel++
 
@@ -163,7 +196,7 @@ func (v *cognitiveComplexityVisitor) walkIfElse(n *ast.IfStmt) {
 		ast.Walk(v, n.Body)
 		if n.Else != nil {
 			if elif, ok := n.Else.(*ast.IfStmt); ok {
-				v.complexity++
+				v.increment(elif.If, keywordEnd(elif.If, token.IF), "else if", 0)
 				w(elif)
 			} else {
 				ast.Walk(v, n.Else)
@@ -173,23 +206,28 @@ func (v *cognitiveComplexityVisitor) walkIfElse(n *ast.IfStmt) {
 
 	// Nesting level is incremented in 'if' and 'else' blocks, but only the first 'if' in an 'if-else-if' chain sees its
 	// complexity increased by the nesting level.
-	v.complexity += 1 + v.nestingLevel
+	v.increment(n.If, keywordEnd(n.If, token.IF), "if", v.nestingLevel)
 	v.nestingLevel++
 	w(n)
 	v.nestingLevel--
 }
 
-func (*cognitiveComplexityVisitor) binExpComplexity(n *ast.BinaryExpr) int {
+// binExpComplexity returns the boolean operators of the expression that increase the complexity,
+// in the order they appear in the source code.
+func (*cognitiveComplexityVisitor) binExpComplexity(n *ast.BinaryExpr) []*ast.BinaryExpr {
 	calculator := binExprComplexityCalculator{opsStack: []token.Token{}}
 
 	astutil.Apply(n, calculator.pre, calculator.post)
 
-	return calculator.complexity
+	slices.SortFunc(calculator.increments, func(a, b *ast.BinaryExpr) int {
+		return cmp.Compare(a.OpPos, b.OpPos)
+	})
+	return calculator.increments
 }
 
 type binExprComplexityCalculator struct {
-	complexity    int
-	opsStack      []token.Token // stack of bool operators
+	increments    []*ast.BinaryExpr // the operators increasing the complexity
+	opsStack      []token.Token     // stack of bool operators
 	subexpStarted bool
 }
 
@@ -209,7 +247,7 @@ func (becc *binExprComplexityCalculator) pre(c *astutil.Cursor) bool {
 		// then
 		//      increment complexity
 		if ops == 0 || becc.subexpStarted || n.Op != becc.opsStack[ops-1] {
-			becc.complexity++
+			becc.increments = append(becc.increments, n)
 			becc.subexpStarted = false
 		}
 
@@ -3,8 +3,9 @@ package rule
 import (
 	"fmt"
 	"go/ast"
+	"maps"
+	"slices"
 	"strings"
-	"sync"
 
 	"github.com/mgechev/revive/internal/astutils"
 	"github.com/mgechev/revive/lint"
@@ -15,52 +16,43 @@ type referenceMethod struct {
 	id       *ast.Ident
 }
 
-type pkgMethods struct {
-	pkg     *lint.Package
-	methods map[string]map[string]*referenceMethod
-	mu      *sync.Mutex
-}
+// ConfusingNamingRule lints method names that differ only by capitalization.
+type ConfusingNamingRule struct{}
 
-type packages struct {
-	pkgs []pkgMethods
-	mu   sync.Mutex
+// Apply applies the rule to given file, comparing the names declared in the file only.
+func (r *ConfusingNamingRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+	return r.apply([]*lint.File{file})
 }
 
-func (ps *packages) methodNames(lp *lint.Package) pkgMethods {
-	ps.mu.Lock()
-	defer ps.mu.Unlock()
-
-	for _, pkg := range ps.pkgs {
-		if pkg.pkg == lp {
-			return pkg
-		}
+// ApplyPackage applies the rule to given package.
+//
+// ApplyPackage implements the [lint.PackageRule] interface.
+func (r *ConfusingNamingRule) ApplyPackage(pkg *lint.Package, _ lint.Arguments) []lint.Failure {
+	files := pkg.Files()
+	names := slices.Sorted(maps.Keys(files))
+	sortedFiles := make([]*lint.File, len(names))
+	for i, name := range names {
+		sortedFiles[i] = files[name]
 	}
 
-	pkgm := pkgMethods{pkg: lp, methods: map[string]map[string]*referenceMethod{}, mu: &sync.Mutex{}}
-	ps.pkgs = append(ps.pkgs, pkgm)
-
-	return pkgm
+	return r.apply(sortedFiles)
 }
 
-var allPkgs = packages{pkgs: make([]pkgMethods, 1)}
-
-// ConfusingNamingRule lints method names that differ only by capitalization.
-type ConfusingNamingRule struct{}
-
-// Apply applies the rule to given file.
-func (*ConfusingNamingRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
+func (*ConfusingNamingRule) apply(files []*lint.File) []lint.Failure {
 	var failures []lint.Failure
-	fileAst := file.AST
-	pkgm := allPkgs.methodNames(file.Pkg)
-	walker := lintConfusingNames{
-		fileName: file.Name,
-		pkgm:     pkgm,
-		onFailure: func(failure lint.Failure) {
-			failures = append(failures, failure)
-		},
-	}
+	// methods of the package, by holder and by upper-cased name
+	methods := map[string]map[string]*referenceMethod{}
+	for _, file := range files {
+		walker := lintConfusingNames{
+			fileName: file.Name,
+			methods:  methods,
+			onFailure: func(failure lint.Failure) {
+				failures = append(failures, failure)
+			},
+		}
 
-	ast.Walk(&walker, fileAst)
+		ast.Walk(&walker, file.AST)
+	}
 
 	return failures
 }
@@ -77,15 +69,12 @@ func checkMethodName(holder string, id *ast.Ident, w *lintConfusingNames) {
 		return
 	}
 
-	pkgm := w.pkgm
+	methods := w.methods
 	name := strings.ToUpper(id.Name)
 
-	pkgm.mu.Lock()
-	defer pkgm.mu.Unlock()
-
-	if pkgm.methods[holder] != nil {
-		if pkgm.methods[holder][name] != nil {
-			refMethod := pkgm.methods[holder][name]
+	if methods[holder] != nil {
+		if methods[holder][name] != nil {
+			refMethod := methods[holder][name]
 			// confusing names
 			var kind string
 			if holder == defaultStructName {
@@ -104,21 +93,22 @@ func checkMethodName(holder string, id *ast.Ident, w *lintConfusingNames) {
 				Confidence: 1,
 				Node:       id,
 				Category:   lint.FailureCategoryNaming,
+				Related:    []lint.RelatedLocation{{Message: fmt.Sprintf("the %s '%s'", kind, refMethod.id.Name), Node: refMethod.id}},
 			})
 
 			return
 		}
 	} else {
-		pkgm.methods[holder] = make(map[string]*referenceMethod, 1)
+		methods[holder] = make(map[string]*referenceMethod, 1)
 	}
 
 	// update the block list
-	pkgm.methods[holder][name] = &referenceMethod{fileName: w.fileName, id: id}
+	methods[holder][name] = &referenceMethod{fileName: w.fileName, id: id}
 }
 
 type lintConfusingNames struct {
 	fileName  string
-	pkgm      pkgMethods
+	methods   map[string]map[string]*referenceMethod
 	onFailure func(lint.Failure)
 }
 
@@ -164,19 +154,20 @@ func extractFromIndexExpr(expr *ast.IndexExpr) string {
 }
 
 func checkStructFields(fields *ast.FieldList, structName string, w *lintConfusingNames) {
-	bl := make(map[string]bool, len(fields.List))
+	bl := make(map[string]*ast.Ident, len(fields.List))
 	for _, f := range fields.List {
 		for _, id := range f.Names {
 			normName := strings.ToUpper(id.Name)
-			if bl[normName] {
+			if other, ok := bl[normName]; ok {
 				w.onFailure(lint.Failure{
 					Failure:    fmt.Sprintf("Field '%s' differs only by capitalization to other field in the struct type %s", id.Name, structName),
 					Confidence: 1,
 					Node:       id,
 					Category:   lint.FailureCategoryNaming,
+					Related:    []lint.RelatedLocation{{Message: fmt.Sprintf("the field '%s'", other.Name), Node: other}},
 				})
 			} else {
-				bl[normName] = true
+				bl[normName] = id
 			}
 		}
 	}
@@ -61,6 +61,10 @@ func (w *lintIdenticalBranches) Visit(node ast.Node) ast.Visitor {
 			Node:       ifStmt,
 			Category:   lint.FailureCategoryLogic,
 			Failure:    "both branches of the if are identical",
+			Related: []lint.RelatedLocation{
+				{Message: "the if branch", Node: ifStmt.Body},
+				{Message: "is identical to the else branch", Node: elseBranch},
+			},
 		})
 	}
 
@@ -16,9 +16,9 @@ type ImportShadowingRule struct{}
 func (*ImportShadowingRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
 	var failures []lint.Failure
 
-	importNames := map[string]struct{}{}
+	importNames := map[string]*ast.ImportSpec{}
 	for _, imp := range file.AST.Imports {
-		importNames[getName(imp)] = struct{}{}
+		importNames[getName(imp)] = imp
 	}
 
 	fileAst := file.AST
@@ -60,7 +60,7 @@ func getName(imp *ast.ImportSpec) string {
 
 type importShadowing struct {
 	packageNameIdent *ast.Ident
-	importNames      map[string]struct{}
+	importNames      map[string]*ast.ImportSpec
 	onFailure        func(lint.Failure)
 	alreadySeen      map[*ast.Object]struct{} //nolint:staticcheck // TODO: ast.Object is deprecated
 	skipIdents       map[*ast.Ident]struct{}
@@ -96,7 +96,7 @@ func (w importShadowing) Visit(n ast.Node) ast.Visitor {
 			return w // skip _ id
 		}
 
-		_, isImportName := w.importNames[id]
+		imp, isImportName := w.importNames[id]
 		_, alreadySeen := w.alreadySeen[n.Obj]
 		_, skipIdent := w.skipIdents[n]
 		if isImportName && !alreadySeen && !skipIdent {
@@ -105,6 +105,7 @@ func (w importShadowing) Visit(n ast.Node) ast.Visitor {
 				Node:       n,
 				Category:   lint.FailureCategoryNaming,
 				Failure:    fmt.Sprintf("The name '%s' shadows an import name", id),
+				Related:    []lint.RelatedLocation{{Message: fmt.Sprintf("the import of %s", imp.Path.Value), Node: imp}},
 			})
 
 			w.alreadySeen[n.Obj] = struct{}{}
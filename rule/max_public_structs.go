@@ -4,14 +4,17 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/mgechev/revive/lint"
 )
 
-// MaxPublicStructsRule lints the number of public structs in a file.
+// MaxPublicStructsRule lints the number of public structs in a file, or in a package.
 type MaxPublicStructsRule struct {
-	max int64
+	max          int64
+	packageScope bool
 }
 
 const defaultMaxPublicStructs = 5
@@ -35,9 +38,59 @@ func (r *MaxPublicStructsRule) Configure(arguments lint.Arguments) error {
 		return errors.New(`invalid value passed as argument number to the "max-public-structs" rule`)
 	}
 	r.max = maxStructs
+
+	if len(arguments) < 2 {
+		return nil
+	}
+	scope, ok := arguments[1].(string)
+	switch {
+	case ok && isRuleOption(scope, "file"):
+		r.packageScope = false
+	case ok && isRuleOption(scope, "package"):
+		r.packageScope = true
+	default:
+		return fmt.Errorf(`invalid scope %v passed to the "max-public-structs" rule, expected "file" or "package"`, arguments[1])
+	}
 	return nil
 }
 
+// ApplyPackage applies the rule to given package, counting the public structs
+// file by file, or of the whole package depending on the configured scope.
+//
+// ApplyPackage implements the [lint.PackageRule] interface.
+func (r *MaxPublicStructsRule) ApplyPackage(pkg *lint.Package, args lint.Arguments) []lint.Failure {
+	files := pkg.Files()
+	names := slices.Sorted(maps.Keys(files))
+	if !r.packageScope {
+		var failures []lint.Failure
+		for _, name := range names {
+			failures = append(failures, r.Apply(files[name], args)...)
+		}
+		return failures
+	}
+
+	if r.max < 1 {
+		return nil
+	}
+
+	walker := &lintMaxPublicStructs{}
+	for _, name := range names {
+		ast.Walk(walker, files[name].AST)
+	}
+
+	if walker.current <= r.max {
+		return nil
+	}
+
+	// the failure is reported on the first declaration exceeding the maximum
+	return []lint.Failure{{
+		Failure:    fmt.Sprintf("you have exceeded the maximum number (%d) of public struct declarations in package %s", r.max, files[names[0]].AST.Name.Name),
+		Confidence: 1,
+		Node:       walker.names[r.max],
+		Category:   lint.FailureCategoryStyle,
+	}}
+}
+
 // Apply applies the rule to given file.
 func (r *MaxPublicStructsRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
 	var failures []lint.Failure
@@ -76,6 +129,7 @@ func (*MaxPublicStructsRule) Name() string {
 
 type lintMaxPublicStructs struct {
 	current   int64
+	names     []*ast.Ident
 	fileAst   *ast.File
 	onFailure func(lint.Failure)
 }
@@ -86,6 +140,7 @@ func (w *lintMaxPublicStructs) Visit(n ast.Node) ast.Visitor {
 		first := string(name[0])
 		if strings.ToUpper(first) == first {
 			w.current++
+			w.names = append(w.names, v.Name)
 		}
 	}
 	return w
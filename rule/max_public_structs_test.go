@@ -0,0 +1,82 @@
+package rule
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+)
+
+func TestMaxPublicStructsRule_Configure(t *testing.T) {
+	tests := []struct {
+		name             string
+		arguments        lint.Arguments
+		wantErr          error
+		wantMax          int64
+		wantPackageScope bool
+	}{
+		{
+			name:      "no arguments",
+			arguments: lint.Arguments{},
+			wantErr:   nil,
+			wantMax:   defaultMaxPublicStructs,
+		},
+		{
+			name:      "max",
+			arguments: lint.Arguments{int64(3)},
+			wantErr:   nil,
+			wantMax:   3,
+		},
+		{
+			name:      "file scope",
+			arguments: lint.Arguments{int64(3), "file"},
+			wantErr:   nil,
+			wantMax:   3,
+		},
+		{
+			name:             "package scope",
+			arguments:        lint.Arguments{int64(10), "package"},
+			wantErr:          nil,
+			wantMax:          10,
+			wantPackageScope: true,
+		},
+		{
+			name:      "invalid max",
+			arguments: lint.Arguments{"3"},
+			wantErr:   errors.New(`invalid value passed as argument number to the "max-public-structs" rule`),
+		},
+		{
+			name:      "invalid scope",
+			arguments: lint.Arguments{int64(3), "module"},
+			wantErr:   errors.New(`invalid scope module passed to the "max-public-structs" rule, expected "file" or "package"`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rule MaxPublicStructsRule
+
+			err := rule.Configure(tt.arguments)
+
+			if tt.wantErr != nil {
+				if err == nil {
+					t.Errorf("unexpected error: got = nil, want = %v", tt.wantErr)
+					return
+				}
+				if err.Error() != tt.wantErr.Error() {
+					t.Errorf("unexpected error: got = %v, want = %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Errorf("unexpected error: got = %v, want = nil", err)
+			}
+			if rule.max != tt.wantMax {
+				t.Errorf("unexpected max: got = %v, want %v", rule.max, tt.wantMax)
+			}
+			if rule.packageScope != tt.wantPackageScope {
+				t.Errorf("unexpected packageScope: got = %v, want %v", rule.packageScope, tt.wantPackageScope)
+			}
+		})
+	}
+}
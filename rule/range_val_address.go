@@ -16,7 +16,7 @@ type RangeValAddress struct{}
 func (*RangeValAddress) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
 	var failures []lint.Failure
 
-	if file.Pkg.IsAtLeastGoVersion(lint.Go122) {
+	if file.IsAtLeastGoVersion(lint.Go122) {
 		return failures
 	}
 
@@ -14,7 +14,7 @@ type RedundantTestMainExitRule struct{}
 func (*RedundantTestMainExitRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure {
 	var failures []lint.Failure
 
-	if !file.IsTest() || !file.Pkg.IsAtLeastGoVersion(lint.Go115) {
+	if !file.IsTest() || !file.IsAtLeastGoVersion(lint.Go115) {
 		// skip analysis for non-test files or for Go versions before 1.15
 		return failures
 	}
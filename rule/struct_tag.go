@@ -115,7 +115,7 @@ func (r *StructTagRule) Apply(file *lint.File, _ lint.Arguments) []lint.Failure
 	w := lintStructTagRule{
 		onFailure:      onFailure,
 		userDefined:    r.userDefined,
-		isAtLeastGo124: file.Pkg.IsAtLeastGoVersion(lint.Go124),
+		isAtLeastGo124: file.IsAtLeastGoVersion(lint.Go124),
 		tagCheckers:    tagCheckers,
 	}
 
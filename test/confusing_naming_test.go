@@ -9,3 +9,7 @@ import (
 func TestConfusingNaming(t *testing.T) {
 	testRule(t, "confusing_naming1", &rule.ConfusingNamingRule{})
 }
+
+func TestConfusingNamingInPackage(t *testing.T) {
+	testPackageRule(t, "confusing_naming_package", &rule.ConfusingNamingRule{})
+}
@@ -16,3 +16,9 @@ func TestMaxPublicStructs(t *testing.T) {
 func TestMaxPublicStructsDefaultConfig(t *testing.T) {
 	testRule(t, "max_public_structs_ok", &rule.MaxPublicStructsRule{}, &lint.RuleConfig{})
 }
+
+func TestMaxPublicStructsInPackage(t *testing.T) {
+	testPackageRule(t, "max_public_structs_package", &rule.MaxPublicStructsRule{}, &lint.RuleConfig{
+		Arguments: []any{int64(2), "package"},
+	})
+}
@@ -14,3 +14,7 @@ func TestRangeValAddress(t *testing.T) {
 func TestRangeValAddressAfterGo1_22(t *testing.T) {
 	testRule(t, "go1.22/range_val_address", &rule.RangeValAddress{}, &lint.RuleConfig{})
 }
+
+func TestRangeValAddressWithGo1_21BuildConstraint(t *testing.T) {
+	testRule(t, "go1.22/range_val_address_go1.21", &rule.RangeValAddress{}, &lint.RuleConfig{})
+}
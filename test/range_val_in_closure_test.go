@@ -14,3 +14,7 @@ func TestRangeValInClosure(t *testing.T) {
 func TestRangeValInClosureAfterGo1_22(t *testing.T) {
 	testRule(t, "go1.22/range_val_in_closure", &rule.RangeValInClosureRule{}, &lint.RuleConfig{})
 }
+
+func TestRangeValInClosureWithGo1_22BuildConstraint(t *testing.T) {
+	testRule(t, "range_val_in_closure_go1.22", &rule.RangeValInClosureRule{}, &lint.RuleConfig{})
+}
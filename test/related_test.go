@@ -0,0 +1,78 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/mgechev/revive/lint"
+	"github.com/mgechev/revive/rule"
+)
+
+// lintRelated lints a testdata file with a rule, and returns the related locations of its first failure.
+func lintRelated(t *testing.T, filename string, r lint.Rule, arguments lint.Arguments) []lint.RelatedLocation {
+	t.Helper()
+
+	configureRule(t, r, arguments)
+	l := lint.New(os.ReadFile, 0)
+	failures, err := l.Lint([][]string{{filepath.Join("..", "testdata", filename)}}, []lint.Rule{r}, lint.Config{
+		Rules: map[string]lint.RuleConfig{r.Name(): {Arguments: arguments}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var related []lint.RelatedLocation
+	for failure := range failures {
+		if related == nil {
+			related = failure.Related
+		}
+	}
+	if related == nil {
+		t.Fatalf("no related locations for %s", filename)
+	}
+	return related
+}
+
+func TestIdenticalBranchesRelated(t *testing.T) {
+	related := lintRelated(t, "identical_branches.go", &rule.IdenticalBranchesRule{}, nil)
+
+	want := []struct {
+		message string
+		line    int
+	}{
+		{"the if branch", 4},
+		{"is identical to the else branch", 6},
+	}
+	if len(related) != len(want) {
+		t.Fatalf("got %d related locations, want %d", len(related), len(want))
+	}
+	for i, w := range want {
+		if related[i].Message != w.message || related[i].Position.Start.Line != w.line {
+			t.Errorf("related location %d: got %q at line %d, want %q at line %d",
+				i, related[i].Message, related[i].Position.Start.Line, w.message, w.line)
+		}
+	}
+}
+
+func TestCognitiveComplexityRelated(t *testing.T) {
+	related := lintRelated(t, "cognitive_complexity.go", &rule.CognitiveComplexityRule{}, lint.Arguments{int64(0)})
+
+	want := []struct {
+		message string
+		column  int
+	}{
+		{"+1 (if)", 2},
+		{"+1 (&&)", 11},
+		{"+1 (||)", 19},
+	}
+	if len(related) != len(want) {
+		t.Fatalf("got %d related locations, want %d", len(related), len(want))
+	}
+	for i, w := range want {
+		if related[i].Message != w.message || related[i].Position.Start.Line != 17 || related[i].Position.Start.Column != w.column {
+			t.Errorf("related location %d: got %q at %d:%d, want %q at 17:%d",
+				i, related[i].Message, related[i].Position.Start.Line, related[i].Position.Start.Column, w.message, w.column)
+		}
+	}
+}
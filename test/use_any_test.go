@@ -9,3 +9,7 @@ import (
 func TestUseAny(t *testing.T) {
 	testRule(t, "use_any", &rule.UseAnyRule{})
 }
+
+func TestUseAnyBeforeGo1_18(t *testing.T) {
+	testRule(t, "go1.15/use_any", &rule.UseAnyRule{})
+}
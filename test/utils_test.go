@@ -99,6 +99,57 @@ func assertFailures(t *testing.T, filePath string, rules []lint.Rule, config map
 		failures = append(failures, f)
 	}
 
+	checkFailures(t, filePath, failures, ins)
+
+	return nil
+}
+
+// testPackageRule lints the files of a testdata directory as a single package,
+// and checks the failures against the instructions of each file.
+func testPackageRule(t *testing.T, dir string, rule lint.Rule, config ...*lint.RuleConfig) {
+	t.Helper()
+
+	filePaths, err := filepath.Glob(filepath.Join("..", "testdata", dir, "*.go"))
+	if err != nil || len(filePaths) == 0 {
+		t.Fatalf("Bad directory path in test for %s: %v", rule.Name(), err)
+	}
+
+	var ruleConfig lint.RuleConfig
+	c := map[string]lint.RuleConfig{}
+	if len(config) > 0 {
+		ruleConfig = *config[0]
+		c[rule.Name()] = ruleConfig
+	}
+	configureRule(t, rule, ruleConfig.Arguments)
+
+	l := lint.New(os.ReadFile, 0)
+	ps, err := l.Lint([][]string{filePaths}, []lint.Rule{rule}, lint.Config{
+		Rules: c,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	failures := map[string][]lint.Failure{}
+	for f := range ps {
+		// failures can refer to other files of the package, match them with slash-separated paths
+		f.Failure = strings.ReplaceAll(f.Failure, string(filepath.Separator), "/")
+		failures[f.Position.Start.Filename] = append(failures[f.Position.Start.Filename], f)
+	}
+
+	for _, filePath := range filePaths {
+		src, err := os.ReadFile(filePath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkFailures(t, filePath, failures[filePath], parseInstructions(t, filePath, src))
+	}
+}
+
+// checkFailures checks the failures reported for a file against the instructions of the file.
+func checkFailures(t *testing.T, filePath string, failures []lint.Failure, ins []instruction) {
+	t.Helper()
+
 	type simplifiedFailure struct {
 		File    string
 		Line    int
@@ -194,8 +245,6 @@ func assertFailures(t *testing.T, filePath string, rules []lint.Rule, config map
 	if errorMessage != "" {
 		t.Error(errorMessage)
 	}
-
-	return nil
 }
 
 type instruction struct {
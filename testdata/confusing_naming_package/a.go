@@ -0,0 +1,12 @@
+// Test of confusing-naming rule across the files of a package.
+
+// Package pkg ...
+package pkg
+
+type foo struct{}
+
+func (t *foo) aFoo() {}
+
+func aGlobal() {}
+
+func bGlobal() {}
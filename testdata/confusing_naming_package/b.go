@@ -0,0 +1,8 @@
+package pkg
+
+func (t *foo) AFoo() {} // MATCH /Method 'AFoo' differs only by capitalization to method 'aFoo' in ../testdata/confusing_naming_package/a.go/
+
+func AGlobal() {} // MATCH /Method 'AGlobal' differs only by capitalization to function 'aGlobal' in ../testdata/confusing_naming_package/a.go/
+
+//revive:disable-next-line:confusing-naming
+func BGlobal() {}
@@ -0,0 +1,7 @@
+package pkg
+
+var i interface{} // the any alias is not available before Go 1.18
+
+func any1(a interface{}) interface{} {
+	return a
+}
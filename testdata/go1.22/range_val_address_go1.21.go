@@ -0,0 +1,13 @@
+//go:build go1.21
+
+package fixtures
+
+func rangeValAddress() {
+	m := map[string]*string{}
+
+	mySlice := []string{"A", "B", "C"}
+	for _, value := range mySlice {
+		// the build constraint gives per-loop variables to this file
+		m["address"] = &value // MATCH /suspicious assignment of 'value'. range-loop variables always have the same address/
+	}
+}
@@ -0,0 +1,8 @@
+// Package pkg ...
+package pkg
+
+type Foo struct {
+}
+
+type Bar struct {
+}
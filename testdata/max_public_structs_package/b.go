@@ -0,0 +1,7 @@
+package pkg
+
+type baz struct {
+}
+
+type Qux struct { // MATCH /you have exceeded the maximum number (2) of public struct declarations in package pkg/
+}
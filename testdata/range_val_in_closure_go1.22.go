@@ -0,0 +1,16 @@
+//go:build go1.22
+
+package fixtures
+
+import "fmt"
+
+func foo() {
+	mySlice := []string{"A", "B", "C"}
+	for index, value := range mySlice {
+		go func() {
+			// the build constraint gives per-iteration loop variables to this file
+			fmt.Printf("Index: %d\n", index)
+			fmt.Printf("Value: %s\n", value)
+		}()
+	}
+}
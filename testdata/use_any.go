@@ -1,3 +1,5 @@
+//go:build go1.18
+
 package pkg
 
 var i interface{} // MATCH /since Go 1.18 'interface{}' can be replaced by 'any'/